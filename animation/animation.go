@@ -0,0 +1,123 @@
+// Package animation interpolates window rects over time. The clock is
+// injectable so the frame scheduling can be tested deterministically on any OS.
+package animation
+
+import (
+	"math"
+	"telewindow/placement"
+	"time"
+)
+
+// Easing names
+const (
+	Linear    = "linear"
+	EaseIn    = "easeIn"
+	EaseOut   = "easeOut"
+	EaseInOut = "easeInOut"
+)
+
+// Easing maps the elapsed fraction of the animation (0 to 1) to the fraction of the distance covered
+type Easing func(t float64) float64
+
+// easings holds the easing functions by name
+var easings = map[string]Easing{
+	Linear: func(t float64) float64 { return t },
+	EaseIn: func(t float64) float64 { return t * t * t },
+	EaseOut: func(t float64) float64 {
+		return 1 - math.Pow(1-t, 3)
+	},
+	EaseInOut: func(t float64) float64 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 3)/2
+	},
+}
+
+// EasingByName returns the easing function with the name
+func EasingByName(name string) (Easing, bool) {
+	easing, ok := easings[name]
+	return easing, ok
+}
+
+// Interpolate returns the rect at the fraction t of the way from one rect to the other
+func Interpolate(from, to placement.Rect, t float64) placement.Rect {
+	lerp := func(a, b int32) int32 {
+		return a + int32(math.Round(float64(b-a)*t))
+	}
+	return placement.Rect{
+		Left:   lerp(from.Left, to.Left),
+		Top:    lerp(from.Top, to.Top),
+		Right:  lerp(from.Right, to.Right),
+		Bottom: lerp(from.Bottom, to.Bottom),
+	}
+}
+
+// Clock tells the time and waits, the animation frames are scheduled with it
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time        { return time.Now() }
+func (SystemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// Animation moves a rect from its start to its end over the duration
+type Animation struct {
+	Duration time.Duration
+	// FrameRate is the number of frames per second, 60 if not set
+	FrameRate int
+	// Easing is the easing function, linear if not set
+	Easing Easing
+	// Clock schedules the frames, the system clock if not set
+	Clock Clock
+}
+
+// Run applies the interpolated rects at a steady frame rate, ending with the target rect.
+// Frames are scheduled at fixed times from the start, so a slow apply drops frames instead of slowing down the animation.
+// It stops at the first error of apply.
+func (a Animation) Run(from, to placement.Rect, apply func(placement.Rect) error) error {
+	if a.Duration <= 0 || from == to {
+		return apply(to)
+	}
+	frameRate := a.FrameRate
+	if frameRate <= 0 {
+		frameRate = 60
+	}
+	easing := a.Easing
+	if easing == nil {
+		easing = easings[Linear]
+	}
+	clock := a.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	frames := max(int(math.Ceil(a.Duration.Seconds()*float64(frameRate))), 1)
+	frameTime := func(frame int) time.Duration {
+		return a.Duration * time.Duration(frame) / time.Duration(frames)
+	}
+
+	start := clock.Now()
+	for frame := 1; frame <= frames; frame++ {
+		// Skip the frames that are already over, the last frame is always applied
+		elapsed := clock.Now().Sub(start)
+		for frame < frames && frameTime(frame) <= elapsed {
+			frame++
+		}
+		if wait := frameTime(frame) - elapsed; wait > 0 {
+			clock.Sleep(wait)
+		}
+
+		if frame == frames {
+			return apply(to)
+		}
+		t := float64(frame) / float64(frames)
+		if err := apply(Interpolate(from, to, easing(t))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
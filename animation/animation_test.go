@@ -0,0 +1,152 @@
+package animation
+
+import (
+	"errors"
+	"reflect"
+	"telewindow/placement"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when the animation sleeps or the test moves it
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func TestInterpolate(t *testing.T) {
+	from := placement.Rect{Left: 0, Top: 0, Right: 960, Bottom: 540}
+	to := placement.Rect{Left: 1920, Top: 100, Right: 3200, Bottom: 1060}
+	tests := []struct {
+		name string
+		t    float64
+		want placement.Rect
+	}{
+		{"start", 0, from},
+		{"quarter", 0.25, placement.Rect{Left: 480, Top: 25, Right: 1520, Bottom: 670}},
+		{"half", 0.5, placement.Rect{Left: 960, Top: 50, Right: 2080, Bottom: 800}},
+		{"end", 1, to},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interpolate(from, to, tt.t); got != tt.want {
+				t.Errorf("Interpolate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEasing(t *testing.T) {
+	tests := []struct {
+		name string
+		half float64
+	}{
+		{Linear, 0.5},
+		{EaseIn, 0.125},
+		{EaseOut, 0.875},
+		{EaseInOut, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			easing, ok := EasingByName(tt.name)
+			if !ok {
+				t.Fatalf("EasingByName(%q) not found", tt.name)
+			}
+			if easing(0) != 0 || easing(1) != 1 {
+				t.Errorf("easing(0), easing(1) = %v, %v, want 0, 1", easing(0), easing(1))
+			}
+			if got := easing(0.5); got != tt.half {
+				t.Errorf("easing(0.5) = %v, want %v", got, tt.half)
+			}
+		})
+	}
+
+	if _, ok := EasingByName("bounce"); ok {
+		t.Error("EasingByName() should not find unknown easings")
+	}
+}
+
+// lefts runs the animation from left 0 to left 100 and returns the left edges of the applied frames
+func lefts(t *testing.T, a Animation, apply func()) []int32 {
+	t.Helper()
+	var got []int32
+	err := a.Run(placement.Rect{Right: 100, Bottom: 100}, placement.Rect{Left: 100, Right: 200, Bottom: 100}, func(r placement.Rect) error {
+		got = append(got, r.Left)
+		if apply != nil {
+			apply()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestRun(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	a := Animation{Duration: 100 * time.Millisecond, FrameRate: 50, Clock: clock}
+
+	if got, want := lefts(t, a, nil), []int32{20, 40, 60, 80, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %v, want %v", got, want)
+	}
+	want := []time.Duration{20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond}
+	if !reflect.DeepEqual(clock.sleeps, want) {
+		t.Errorf("sleeps = %v, want %v", clock.sleeps, want)
+	}
+}
+
+func TestRunEasing(t *testing.T) {
+	easeOut, _ := EasingByName(EaseOut)
+	a := Animation{Duration: 100 * time.Millisecond, FrameRate: 40, Easing: easeOut, Clock: &fakeClock{}}
+
+	if got, want := lefts(t, a, nil), []int32{58, 88, 98, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %v, want %v", got, want)
+	}
+}
+
+func TestRunDropsFrames(t *testing.T) {
+	clock := &fakeClock{}
+	a := Animation{Duration: 100 * time.Millisecond, FrameRate: 50, Clock: clock}
+
+	// Every frame takes 30ms to apply, the frames that are over are skipped and the animation ends on time
+	got := lefts(t, a, func() { clock.now = clock.now.Add(30 * time.Millisecond) })
+	if want := []int32{20, 60, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %v, want %v", got, want)
+	}
+	if end := clock.now.Sub(time.Time{}); end != 130*time.Millisecond {
+		t.Errorf("animation ended after %v, want 130ms", end)
+	}
+}
+
+func TestRunWithoutDuration(t *testing.T) {
+	clock := &fakeClock{}
+	if got, want := lefts(t, Animation{Clock: clock}, nil), []int32{100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("frames = %v, want %v", got, want)
+	}
+	if clock.sleeps != nil {
+		t.Errorf("sleeps = %v, want none", clock.sleeps)
+	}
+}
+
+func TestRunStopsOnError(t *testing.T) {
+	a := Animation{Duration: 100 * time.Millisecond, FrameRate: 50, Clock: &fakeClock{}}
+	frames := 0
+	failed := errors.New("window closed")
+	err := a.Run(placement.Rect{}, placement.Rect{Right: 100, Bottom: 100}, func(placement.Rect) error {
+		frames++
+		return failed
+	})
+	if !errors.Is(err, failed) || frames != 1 {
+		t.Errorf("Run() = %v after %d frames, want %v after 1 frame", err, frames, failed)
+	}
+}
@@ -39,7 +39,27 @@ func main() {
 		log.Println("  -SplitLeft     Split window left")
 		log.Println("  -SplitUp       Split window up")
 		log.Println("  -SplitDown     Split window down")
+		log.Println("  -SplitTopLeft     Split window to the top left quarter")
+		log.Println("  -SplitTopRight    Split window to the top right quarter")
+		log.Println("  -SplitBottomLeft  Split window to the bottom left quarter")
+		log.Println("  -SplitBottomRight Split window to the bottom right quarter")
 		log.Println("  -ToggleMaximize Toggle maximize/restore")
+		log.Println("  -MoveToMonitor <index|alias> Move window to the monitor with the index (1 is the leftmost) or alias")
+		log.Println("  -GridSnap      Snap window to the grid cell under its center")
+		log.Println("  -GridMoveRight Move window one grid cell right (also Left, Up, Down)")
+		log.Println("  -GridGrowRight Grow window one grid cell right (also Left, Up, Down)")
+		log.Println("  -GridShrinkRight Shrink window one grid cell from the right (also Left, Up, Down)")
+		log.Println("  -SwapWithMonitorRight Swap window with the top window on the monitor to the right (also Left, Up, Down)")
+		log.Println("  -MoveAppWindowsRight  Move all windows of the application to the monitor on the right (also Left, Up, Down)")
+		log.Println("  -FocusRight    Focus the nearest window to the right (also Left, Up, Down)")
+		log.Println("  -Gather        Move all windows to the monitor of the active window")
+		log.Println("  -Scatter       Rebalance all windows across the monitors")
+		log.Println("  -Center        Center window on its monitor")
+		log.Println("  -NudgeRight    Nudge window right by the nudge step (also Left, Up, Down)")
+		log.Println("  -GrowRight     Grow the right edge of the window by the nudge step (also Left, Up, Down)")
+		log.Println("  -ShrinkRight   Shrink the right edge of the window by the nudge step (also Left, Up, Down)")
+		log.Println("  layout save <name>    Save the position of all windows as a named layout")
+		log.Println("  layout restore <name> Restore the windows to a named layout")
 		log.Println("  -NoOp 					No Operation (Used to bind over existing shortcuts)")
 		os.Exit(0)
 	}
@@ -48,6 +68,14 @@ func main() {
 
 	log.Println("Received command:", command)
 
+	// The config is optional for the CLI, the defaults are used without it
+	config, err := window.LoadConfig()
+	if err != nil {
+		log.Println("Could not load config, using defaults:", err)
+	} else {
+		window.ApplyConfig(config)
+	}
+
 	switch command {
 	case "-Right":
 		window.MoveActiveWindow(RightDirection)
@@ -69,16 +97,120 @@ func main() {
 		window.SplitActiveWindow(UpDirection)
 	case "-SplitDown":
 		window.SplitActiveWindow(DownDirection)
+	case "-SplitTopLeft":
+		window.SplitActiveWindowCorner(LeftDirection, UpDirection)
+	case "-SplitTopRight":
+		window.SplitActiveWindowCorner(RightDirection, UpDirection)
+	case "-SplitBottomLeft":
+		window.SplitActiveWindowCorner(LeftDirection, DownDirection)
+	case "-SplitBottomRight":
+		window.SplitActiveWindowCorner(RightDirection, DownDirection)
 	case "-ToggleMaximize":
-		maximized, err := window.IsActiveWindowMaximized(nil)
-		if err != nil {
-			log.Println("Error checking if window is maximized:", err)
+		window.ToggleMaximizeActiveWindow()
+	case "-MoveToMonitor":
+		if len(os.Args) < 3 {
+			log.Println("Missing monitor index or alias for", command)
+			exit(1)
+		}
+		window.MoveActiveWindowToMonitor(os.Args[2])
+	case "-GridSnap":
+		window.GridSnapActiveWindow()
+	case "-GridMoveRight":
+		window.GridMoveActiveWindow(RightDirection)
+	case "-GridMoveLeft":
+		window.GridMoveActiveWindow(LeftDirection)
+	case "-GridMoveUp":
+		window.GridMoveActiveWindow(UpDirection)
+	case "-GridMoveDown":
+		window.GridMoveActiveWindow(DownDirection)
+	case "-GridGrowRight":
+		window.GridResizeActiveWindow(RightDirection, true)
+	case "-GridGrowLeft":
+		window.GridResizeActiveWindow(LeftDirection, true)
+	case "-GridGrowUp":
+		window.GridResizeActiveWindow(UpDirection, true)
+	case "-GridGrowDown":
+		window.GridResizeActiveWindow(DownDirection, true)
+	case "-GridShrinkRight":
+		window.GridResizeActiveWindow(RightDirection, false)
+	case "-GridShrinkLeft":
+		window.GridResizeActiveWindow(LeftDirection, false)
+	case "-GridShrinkUp":
+		window.GridResizeActiveWindow(UpDirection, false)
+	case "-GridShrinkDown":
+		window.GridResizeActiveWindow(DownDirection, false)
+	case "-SwapWithMonitorRight":
+		window.SwapActiveWindowWithMonitor(RightDirection)
+	case "-SwapWithMonitorLeft":
+		window.SwapActiveWindowWithMonitor(LeftDirection)
+	case "-SwapWithMonitorUp":
+		window.SwapActiveWindowWithMonitor(UpDirection)
+	case "-SwapWithMonitorDown":
+		window.SwapActiveWindowWithMonitor(DownDirection)
+	case "-MoveAppWindowsRight":
+		window.MoveAppWindows(RightDirection)
+	case "-MoveAppWindowsLeft":
+		window.MoveAppWindows(LeftDirection)
+	case "-MoveAppWindowsUp":
+		window.MoveAppWindows(UpDirection)
+	case "-MoveAppWindowsDown":
+		window.MoveAppWindows(DownDirection)
+	case "-FocusRight":
+		window.FocusWindowInDirection(RightDirection)
+	case "-FocusLeft":
+		window.FocusWindowInDirection(LeftDirection)
+	case "-FocusUp":
+		window.FocusWindowInDirection(UpDirection)
+	case "-FocusDown":
+		window.FocusWindowInDirection(DownDirection)
+	case "-Gather":
+		window.GatherWindows()
+	case "-Scatter", "-Rebalance":
+		window.ScatterWindows()
+	case "-Center":
+		window.CenterActiveWindow()
+	case "-NudgeRight":
+		window.NudgeActiveWindow(RightDirection)
+	case "-NudgeLeft":
+		window.NudgeActiveWindow(LeftDirection)
+	case "-NudgeUp":
+		window.NudgeActiveWindow(UpDirection)
+	case "-NudgeDown":
+		window.NudgeActiveWindow(DownDirection)
+	case "-GrowRight":
+		window.ResizeActiveWindowEdge(RightDirection, true)
+	case "-GrowLeft":
+		window.ResizeActiveWindowEdge(LeftDirection, true)
+	case "-GrowUp":
+		window.ResizeActiveWindowEdge(UpDirection, true)
+	case "-GrowDown":
+		window.ResizeActiveWindowEdge(DownDirection, true)
+	case "-ShrinkRight":
+		window.ResizeActiveWindowEdge(RightDirection, false)
+	case "-ShrinkLeft":
+		window.ResizeActiveWindowEdge(LeftDirection, false)
+	case "-ShrinkUp":
+		window.ResizeActiveWindowEdge(UpDirection, false)
+	case "-ShrinkDown":
+		window.ResizeActiveWindowEdge(DownDirection, false)
+	case "layout":
+		if len(os.Args) < 4 {
+			log.Println("Usage: telewindow layout save|restore <name>")
 			exit(1)
 		}
-		if maximized {
-			window.RestoreActiveWindow(nil)
-		} else {
-			window.MaximizeActiveWindow(nil)
+		var err error
+		switch os.Args[2] {
+		case "save":
+			err = window.SaveSnapshot(os.Args[3])
+		case "restore":
+			err = window.RestoreSnapshot(os.Args[3])
+		default:
+			log.Println("Unknown layout command:", os.Args[2])
+			exit(1)
+		}
+		if err != nil {
+			log.Println("Layout", os.Args[2], "failed:", err)
+			exit(1)
 		}
 	case "-NoOp":
 		// Do nothing
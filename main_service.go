@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 	"telewindow/lumberjack"
@@ -53,8 +54,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Set the global SizeByPixel variable
-	window.SizeByPixel = config.SizeByPixel
+	// Set the global window settings
+	window.ApplyConfig(config)
 
 	// Detect if we are running as admininstrator
 	if !window.IsRunningAsAdmin() {
@@ -83,6 +84,7 @@ func onReady(config *window.Config) {
 	// Set the icon (optional)
 	systray.SetIcon(iconData)
 	systray.SetTooltip("TeleWindow Service")
+	addLayoutMenu()
 	mQuit := systray.AddMenuItem("Quit", "Quit the application")
 	go func() {
 		select {
@@ -101,11 +103,169 @@ func onReady(config *window.Config) {
 	go keyboardHook(signalChan, config)
 }
 
+// trayLayout is the name of the layout saved from the tray menu
+const trayLayout = "tray"
+
+// addLayoutMenu adds the tray menu entries to save the window layout and restore saved layouts.
+// The saved layouts are listed again when a layout is saved and every few seconds, layouts can also be saved with the CLI.
+func addLayoutMenu() {
+	mSave := systray.AddMenuItem("Save Layout", "Save the position of all windows")
+	menu := &layoutMenu{parent: systray.AddMenuItem("Restore Layout", "Restore the windows to a saved layout")}
+	menu.refresh()
+
+	go func() {
+		for range mSave.ClickedCh {
+			if err := window.SaveSnapshot(trayLayout); err != nil {
+				log.Println("Error saving layout:", err)
+			}
+			menu.refresh()
+		}
+	}()
+	go func() {
+		for range time.Tick(5 * time.Second) {
+			menu.refresh()
+		}
+	}()
+	systray.AddSeparator()
+}
+
+// layoutMenu lists the saved layouts in the restore submenu.
+// Tray menu items cannot be removed, so the items are reused and hidden when fewer layouts are saved.
+type layoutMenu struct {
+	mu     sync.Mutex
+	parent *systray.MenuItem
+	items  []*systray.MenuItem
+	names  []string
+}
+
+// refresh updates the submenu to the saved layouts
+func (m *layoutMenu) refresh() {
+	names, err := window.SnapshotNames()
+	if err != nil {
+		log.Println("Error loading saved layouts:", err)
+	}
+	if !slices.Contains(names, trayLayout) {
+		names = append([]string{trayLayout}, names...)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if slices.Equal(names, m.names) {
+		return
+	}
+	m.names = names
+	for i, name := range names {
+		if i == len(m.items) {
+			item := m.parent.AddSubMenuItem(name, "")
+			m.items = append(m.items, item)
+			go m.restoreOnClick(i, item)
+		}
+		m.items[i].SetTitle(name)
+		m.items[i].SetTooltip("Restore the layout " + name)
+		m.items[i].Show()
+	}
+	for _, item := range m.items[len(names):] {
+		item.Hide()
+	}
+}
+
+// restoreOnClick restores the layout listed at the index whenever the item is clicked
+func (m *layoutMenu) restoreOnClick(index int, item *systray.MenuItem) {
+	for range item.ClickedCh {
+		m.mu.Lock()
+		name := ""
+		if index < len(m.names) {
+			name = m.names[index]
+		}
+		m.mu.Unlock()
+		if name == "" {
+			continue
+		}
+		if err := window.RestoreSnapshot(name); err != nil {
+			log.Println("Error restoring layout:", err)
+		}
+	}
+}
+
 func onExit() {
 	// Cleanup tasks
 	log.Println("TeleWindow exited.")
 }
 
+// hotkey binds a key combination to a window action
+type hotkey struct {
+	name    string
+	binding window.KeyBinding
+	action  func()
+}
+
+func hotkeysFromConfig(config *window.Config) []hotkey {
+	kb := config.KeyBindings
+	hotkeys := []hotkey{
+		{"Move Right", kb.MoveRight, func() { window.MoveActiveWindow(RightDirection) }},
+		{"Move Left", kb.MoveLeft, func() { window.MoveActiveWindow(LeftDirection) }},
+		{"Move Up", kb.MoveUp, func() { window.MoveActiveWindow(UpDirection) }},
+		{"Move Down", kb.MoveDown, func() { window.MoveActiveWindow(DownDirection) }},
+		{"Toggle Maximize", kb.ToggleMaximize, func() { window.ToggleMaximizeActiveWindow() }},
+		{"Split Left", kb.SplitLeft, func() { window.SplitActiveWindow(LeftDirection) }},
+		{"Split Right", kb.SplitRight, func() { window.SplitActiveWindow(RightDirection) }},
+		{"Split Up", kb.SplitUp, func() { window.SplitActiveWindow(UpDirection) }},
+		{"Split Down", kb.SplitDown, func() { window.SplitActiveWindow(DownDirection) }},
+		{"Split Top Left", kb.SplitTopLeft, func() { window.SplitActiveWindowCorner(LeftDirection, UpDirection) }},
+		{"Split Top Right", kb.SplitTopRight, func() { window.SplitActiveWindowCorner(RightDirection, UpDirection) }},
+		{"Split Bottom Left", kb.SplitBottomLeft, func() { window.SplitActiveWindowCorner(LeftDirection, DownDirection) }},
+		{"Split Bottom Right", kb.SplitBottomRight, func() { window.SplitActiveWindowCorner(RightDirection, DownDirection) }},
+		{"Grid Snap", kb.GridSnap, func() { window.GridSnapActiveWindow() }},
+		{"Grid Move Left", kb.GridMoveLeft, func() { window.GridMoveActiveWindow(LeftDirection) }},
+		{"Grid Move Right", kb.GridMoveRight, func() { window.GridMoveActiveWindow(RightDirection) }},
+		{"Grid Move Up", kb.GridMoveUp, func() { window.GridMoveActiveWindow(UpDirection) }},
+		{"Grid Move Down", kb.GridMoveDown, func() { window.GridMoveActiveWindow(DownDirection) }},
+		{"Grid Grow Left", kb.GridGrowLeft, func() { window.GridResizeActiveWindow(LeftDirection, true) }},
+		{"Grid Grow Right", kb.GridGrowRight, func() { window.GridResizeActiveWindow(RightDirection, true) }},
+		{"Grid Grow Up", kb.GridGrowUp, func() { window.GridResizeActiveWindow(UpDirection, true) }},
+		{"Grid Grow Down", kb.GridGrowDown, func() { window.GridResizeActiveWindow(DownDirection, true) }},
+		{"Grid Shrink Left", kb.GridShrinkLeft, func() { window.GridResizeActiveWindow(LeftDirection, false) }},
+		{"Grid Shrink Right", kb.GridShrinkRight, func() { window.GridResizeActiveWindow(RightDirection, false) }},
+		{"Grid Shrink Up", kb.GridShrinkUp, func() { window.GridResizeActiveWindow(UpDirection, false) }},
+		{"Grid Shrink Down", kb.GridShrinkDown, func() { window.GridResizeActiveWindow(DownDirection, false) }},
+		{"Swap With Monitor Left", kb.SwapWithMonitorLeft, func() { window.SwapActiveWindowWithMonitor(LeftDirection) }},
+		{"Swap With Monitor Right", kb.SwapWithMonitorRight, func() { window.SwapActiveWindowWithMonitor(RightDirection) }},
+		{"Swap With Monitor Up", kb.SwapWithMonitorUp, func() { window.SwapActiveWindowWithMonitor(UpDirection) }},
+		{"Swap With Monitor Down", kb.SwapWithMonitorDown, func() { window.SwapActiveWindowWithMonitor(DownDirection) }},
+		{"Move App Windows Left", kb.MoveAppWindowsLeft, func() { window.MoveAppWindows(LeftDirection) }},
+		{"Move App Windows Right", kb.MoveAppWindowsRight, func() { window.MoveAppWindows(RightDirection) }},
+		{"Move App Windows Up", kb.MoveAppWindowsUp, func() { window.MoveAppWindows(UpDirection) }},
+		{"Move App Windows Down", kb.MoveAppWindowsDown, func() { window.MoveAppWindows(DownDirection) }},
+		{"Focus Left", kb.FocusLeft, func() { window.FocusWindowInDirection(LeftDirection) }},
+		{"Focus Right", kb.FocusRight, func() { window.FocusWindowInDirection(RightDirection) }},
+		{"Focus Up", kb.FocusUp, func() { window.FocusWindowInDirection(UpDirection) }},
+		{"Focus Down", kb.FocusDown, func() { window.FocusWindowInDirection(DownDirection) }},
+		{"Gather", kb.Gather, func() { window.GatherWindows() }},
+		{"Scatter", kb.Scatter, func() { window.ScatterWindows() }},
+		{"Unsnap", kb.Unsnap, func() { window.UnsnapActiveWindow() }},
+		{"Center", kb.Center, func() { window.CenterActiveWindow() }},
+		{"Nudge Left", kb.NudgeLeft, func() { window.NudgeActiveWindow(LeftDirection) }},
+		{"Nudge Right", kb.NudgeRight, func() { window.NudgeActiveWindow(RightDirection) }},
+		{"Nudge Up", kb.NudgeUp, func() { window.NudgeActiveWindow(UpDirection) }},
+		{"Nudge Down", kb.NudgeDown, func() { window.NudgeActiveWindow(DownDirection) }},
+		{"Grow Left", kb.GrowLeft, func() { window.ResizeActiveWindowEdge(LeftDirection, true) }},
+		{"Grow Right", kb.GrowRight, func() { window.ResizeActiveWindowEdge(RightDirection, true) }},
+		{"Grow Up", kb.GrowUp, func() { window.ResizeActiveWindowEdge(UpDirection, true) }},
+		{"Grow Down", kb.GrowDown, func() { window.ResizeActiveWindowEdge(DownDirection, true) }},
+		{"Shrink Left", kb.ShrinkLeft, func() { window.ResizeActiveWindowEdge(LeftDirection, false) }},
+		{"Shrink Right", kb.ShrinkRight, func() { window.ResizeActiveWindowEdge(RightDirection, false) }},
+		{"Shrink Up", kb.ShrinkUp, func() { window.ResizeActiveWindowEdge(UpDirection, false) }},
+		{"Shrink Down", kb.ShrinkDown, func() { window.ResizeActiveWindowEdge(DownDirection, false) }},
+		{"Undo", kb.Undo, func() { window.UndoActiveWindow() }},
+		{"Redo", kb.Redo, func() { window.RedoActiveWindow() }},
+	}
+	for _, b := range kb.MoveToMonitor {
+		monitor := b.Monitor
+		hotkeys = append(hotkeys, hotkey{"Move To Monitor " + monitor, b.KeyBinding, func() { window.MoveActiveWindowToMonitor(monitor) }})
+	}
+	return hotkeys
+}
+
 func keyboardHook(signalChan chan os.Signal, config *window.Config) error {
 	// Buffer size is depends on your need. The 100 is placeholder value.
 	keyboardChan := make(chan types.KeyboardEvent, 100)
@@ -121,11 +281,64 @@ func keyboardHook(signalChan chan os.Signal, config *window.Config) error {
 
 	var lastMove time.Time = time.Now()
 
+	hotkeys := hotkeysFromConfig(config)
+
+	// Apply the rules to opened windows and re-tile the monitors when windows are opened, closed, minimized or restored
+	var watcher *window.WindowWatcher
+	var windowEvents <-chan struct{}
+	// watchSettle delays the poll after window events, windows are often placed right after they are shown
+	var watchSettle <-chan time.Time
+	if window.Tiling {
+		window.RetileMonitors()
+	}
+	if window.Tiling || window.Rules.Len() > 0 {
+		watcher = window.NewWindowWatcher()
+		windowEvents = window.WindowEvents()
+	}
+
+	// Restore the layout of the monitor topology when displays are connected or disconnected
+	var topologyWatcher *window.TopologyWatcher
+	var displayChanges <-chan struct{}
+	// topologySettle polls the topology again after a display change until it is stable
+	var topologySettle <-chan time.Time
+	if window.AutoRestoreLayouts {
+		topologyWatcher = window.NewTopologyWatcher()
+		displayChanges = window.DisplayChanges()
+		windowEvents = window.WindowEvents()
+	}
+
 	for {
 		select {
 		case <-signalChan:
 			log.Println("Received shutdown signal")
 			return nil
+		case <-windowEvents:
+			if watchSettle == nil {
+				watchSettle = time.After(200 * time.Millisecond)
+			}
+		case <-watchSettle:
+			watchSettle = nil
+			if watcher != nil {
+				created, _, changed := watcher.Poll()
+				for _, hwnd := range created {
+					window.ApplyRules(hwnd)
+				}
+				if window.Tiling && changed {
+					window.RetileMonitors()
+				}
+			}
+			if topologyWatcher != nil {
+				topologyWatcher.Capture()
+			}
+		case <-displayChanges:
+			topologyWatcher.Poll()
+			topologySettle = time.After(2 * time.Second)
+		case <-topologySettle:
+			topologySettle = nil
+			topologyWatcher.Poll()
+			if topologyWatcher.Pending() {
+				topologySettle = time.After(2 * time.Second)
+			}
 		case k := <-keyboardChan:
 			// log.Printf("Received %v %v\n", k.Message, k.VKCode)
 			msg := fmt.Sprint(k.Message)
@@ -140,55 +353,15 @@ func keyboardHook(signalChan chan os.Signal, config *window.Config) error {
 				keyDownMap[key] = true
 				// fmt.Println(keyDownMap)
 
-				// If control and right arrow are pressed
+				// Run the action of the first hotkey that is pressed
 				if time.Since(lastMove) > 50*time.Millisecond {
-					if config.KeyBindings.MoveRight.Down(keyDownMap) {
-						log.Println("Hotkey Move Right Pressed")
-						window.MoveActiveWindow(RightDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.MoveLeft.Down(keyDownMap) {
-						log.Println("Hotkey Move Left Pressed")
-						window.MoveActiveWindow(LeftDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.MoveUp.Down(keyDownMap) {
-						log.Println("Hotkey Move Up Pressed")
-						window.MoveActiveWindow(UpDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.MoveDown.Down(keyDownMap) {
-						log.Println("Hotkey Move Down Pressed")
-						window.MoveActiveWindow(DownDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.ToggleMaximize.Down(keyDownMap) {
-						log.Println("Hotkey Toggle Maximize Pressed")
-						maximized, err := window.IsActiveWindowMaximized(nil)
-						if err != nil {
-							log.Println("Error checking if window is maximized:", err)
-							continue
-						}
-						if maximized {
-							log.Println("Window is maximized, restoring window.")
-							window.RestoreActiveWindow(nil)
-						} else {
-							log.Println("Window is not maximized, maximizing window.")
-							window.MaximizeActiveWindow(nil)
+					for _, h := range hotkeys {
+						if h.binding.Down(keyDownMap) {
+							log.Printf("Hotkey %s Pressed\n", h.name)
+							h.action()
+							lastMove = time.Now()
+							break
 						}
-						lastMove = time.Now()
-					} else if config.KeyBindings.SplitLeft.Down(keyDownMap) {
-						log.Println("Hotkey Split Left Pressed")
-						window.SplitActiveWindow(LeftDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.SplitRight.Down(keyDownMap) {
-						log.Println("Hotkey Split Right Pressed")
-						window.SplitActiveWindow(RightDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.SplitUp.Down(keyDownMap) {
-						log.Println("Hotkey Split Up Pressed")
-						window.SplitActiveWindow(UpDirection)
-						lastMove = time.Now()
-					} else if config.KeyBindings.SplitDown.Down(keyDownMap) {
-						log.Println("Hotkey Split Down Pressed")
-						window.SplitActiveWindow(DownDirection)
-						lastMove = time.Now()
 					}
 				}
 			} else if up && keyDownMap[key] {
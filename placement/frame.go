@@ -0,0 +1,46 @@
+package placement
+
+// Frame is the size of the invisible resize borders around the visible frame of a window.
+// On Windows 10 and later the window rect includes these borders, so windows placed by their window rect
+// show gaps next to each other and next to the monitor edges.
+type Frame struct {
+	Left, Top, Right, Bottom int32
+}
+
+// FrameOf returns the invisible borders from the window rect and the visible frame (the extended frame bounds).
+// An empty frame is returned if the visible frame does not lie within the window rect.
+func FrameOf(window, visible Rect) Frame {
+	if visible.Width() <= 0 || visible.Height() <= 0 {
+		return Frame{}
+	}
+	frame := Frame{
+		Left:   visible.Left - window.Left,
+		Top:    visible.Top - window.Top,
+		Right:  window.Right - visible.Right,
+		Bottom: window.Bottom - visible.Bottom,
+	}
+	if frame.Left < 0 || frame.Top < 0 || frame.Right < 0 || frame.Bottom < 0 {
+		return Frame{}
+	}
+	return frame
+}
+
+// Visible translates a window rect to the visible frame
+func (f Frame) Visible(window Rect) Rect {
+	return Rect{
+		Left:   window.Left + f.Left,
+		Top:    window.Top + f.Top,
+		Right:  window.Right - f.Right,
+		Bottom: window.Bottom - f.Bottom,
+	}
+}
+
+// Window translates a visible frame back to the window rect passed to MoveWindow
+func (f Frame) Window(visible Rect) Rect {
+	return Rect{
+		Left:   visible.Left - f.Left,
+		Top:    visible.Top - f.Top,
+		Right:  visible.Right + f.Right,
+		Bottom: visible.Bottom + f.Bottom,
+	}
+}
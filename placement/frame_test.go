@@ -0,0 +1,51 @@
+package placement
+
+import "testing"
+
+func TestFrameOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  Rect
+		visible Rect
+		want    Frame
+	}{
+		{"windows 10 borders", Rect{-7, 0, 967, 1047}, Rect{0, 0, 960, 1040}, Frame{7, 0, 7, 7}},
+		{"no borders", Rect{0, 0, 960, 1040}, Rect{0, 0, 960, 1040}, Frame{}},
+		{"empty visible frame", Rect{0, 0, 960, 1040}, Rect{}, Frame{}},
+		{"visible frame outside the window", Rect{0, 0, 960, 1040}, Rect{-5, 0, 960, 1040}, Frame{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FrameOf(tt.window, tt.visible); got != tt.want {
+				t.Errorf("FrameOf() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameTranslation(t *testing.T) {
+	frame := Frame{Left: 7, Top: 0, Right: 7, Bottom: 7}
+	area := Rect{0, 0, 1920, 1040}
+
+	// Split halves computed on the visible frame meet at the seam and touch the monitor edges
+	left, _ := Split(area, Left)
+	right, _ := Split(area, Right)
+
+	leftWindow := frame.Window(left)
+	rightWindow := frame.Window(right)
+	if want := (Rect{-7, 0, 967, 1047}); leftWindow != want {
+		t.Errorf("left window rect = %+v, want %+v", leftWindow, want)
+	}
+	if want := (Rect{953, 0, 1927, 1047}); rightWindow != want {
+		t.Errorf("right window rect = %+v, want %+v", rightWindow, want)
+	}
+
+	// The translation is reversible
+	if got := frame.Visible(leftWindow); got != left {
+		t.Errorf("Visible(Window(left)) = %+v, want %+v", got, left)
+	}
+	if got := frame.Visible(rightWindow); got.Left != left.Right {
+		t.Errorf("visible seam mismatch: left ends at %d, right starts at %d", left.Right, got.Left)
+	}
+}
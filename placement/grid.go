@@ -0,0 +1,161 @@
+package placement
+
+// Grid divides an area into equally sized cells
+type Grid struct {
+	Columns int `json:"columns"`
+	Rows    int `json:"rows"`
+}
+
+// Span is a rectangular block of grid cells
+type Span struct {
+	Column, Row   int
+	Columns, Rows int
+}
+
+// Valid checks if the grid has at least one cell
+func (g Grid) Valid() bool {
+	return g.Columns > 0 && g.Rows > 0
+}
+
+// columnEdge returns the x coordinate of the vertical grid line with the given index
+func (g Grid) columnEdge(area Rect, index int) int32 {
+	return area.Left + int32(int64(area.Width())*int64(index)/int64(g.Columns))
+}
+
+// rowEdge returns the y coordinate of the horizontal grid line with the given index
+func (g Grid) rowEdge(area Rect, index int) int32 {
+	return area.Top + int32(int64(area.Height())*int64(index)/int64(g.Rows))
+}
+
+// Rect calculates the rect covered by the span
+func (g Grid) Rect(area Rect, span Span) Rect {
+	return Rect{
+		Left:   g.columnEdge(area, span.Column),
+		Top:    g.rowEdge(area, span.Row),
+		Right:  g.columnEdge(area, span.Column+span.Columns),
+		Bottom: g.rowEdge(area, span.Row+span.Rows),
+	}
+}
+
+// CellAt returns the single cell that contains the center of the window
+func (g Grid) CellAt(area, window Rect) Span {
+	centerX := window.Left + window.Width()/2
+	centerY := window.Top + window.Height()/2
+
+	column := 0
+	for column < g.Columns-1 && centerX >= g.columnEdge(area, column+1) {
+		column++
+	}
+	row := 0
+	for row < g.Rows-1 && centerY >= g.rowEdge(area, row+1) {
+		row++
+	}
+	return Span{Column: column, Row: row, Columns: 1, Rows: 1}
+}
+
+// SpanOf returns the span closest to the window by snapping every edge of the window to the nearest grid line
+func (g Grid) SpanOf(area, window Rect) Span {
+	left := nearestLine(window.Left, g.Columns, func(i int) int32 { return g.columnEdge(area, i) })
+	right := nearestLine(window.Right, g.Columns, func(i int) int32 { return g.columnEdge(area, i) })
+	top := nearestLine(window.Top, g.Rows, func(i int) int32 { return g.rowEdge(area, i) })
+	bottom := nearestLine(window.Bottom, g.Rows, func(i int) int32 { return g.rowEdge(area, i) })
+
+	// A span covers at least one cell
+	if right <= left {
+		if left == g.Columns {
+			left--
+		}
+		right = left + 1
+	}
+	if bottom <= top {
+		if top == g.Rows {
+			top--
+		}
+		bottom = top + 1
+	}
+	return Span{Column: left, Row: top, Columns: right - left, Rows: bottom - top}
+}
+
+// nearestLine returns the index of the grid line closest to the coordinate
+func nearestLine(coordinate int32, cells int, edge func(int) int32) int {
+	nearest := 0
+	for i := 1; i <= cells; i++ {
+		if abs(edge(i)-coordinate) < abs(edge(nearest)-coordinate) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+// Move moves the span one cell in the direction, the span stays within the grid
+func (g Grid) Move(span Span, direction int) Span {
+	switch direction {
+	case Left:
+		if span.Column > 0 {
+			span.Column--
+		}
+	case Right:
+		if span.Column+span.Columns < g.Columns {
+			span.Column++
+		}
+	case Up:
+		if span.Row > 0 {
+			span.Row--
+		}
+	case Down:
+		if span.Row+span.Rows < g.Rows {
+			span.Row++
+		}
+	}
+	return span
+}
+
+// Grow extends the edge of the span in the direction by one cell
+func (g Grid) Grow(span Span, direction int) Span {
+	switch direction {
+	case Left:
+		if span.Column > 0 {
+			span.Column--
+			span.Columns++
+		}
+	case Right:
+		if span.Column+span.Columns < g.Columns {
+			span.Columns++
+		}
+	case Up:
+		if span.Row > 0 {
+			span.Row--
+			span.Rows++
+		}
+	case Down:
+		if span.Row+span.Rows < g.Rows {
+			span.Rows++
+		}
+	}
+	return span
+}
+
+// Shrink pulls the edge of the span in the direction in by one cell, a span never gets smaller than one cell
+func (g Grid) Shrink(span Span, direction int) Span {
+	switch direction {
+	case Left:
+		if span.Columns > 1 {
+			span.Column++
+			span.Columns--
+		}
+	case Right:
+		if span.Columns > 1 {
+			span.Columns--
+		}
+	case Up:
+		if span.Rows > 1 {
+			span.Row++
+			span.Rows--
+		}
+	case Down:
+		if span.Rows > 1 {
+			span.Rows--
+		}
+	}
+	return span
+}
@@ -0,0 +1,101 @@
+package placement
+
+import "testing"
+
+func TestGridRect(t *testing.T) {
+	grid := Grid{Columns: 3, Rows: 2}
+	area := Rect{0, 0, 3440, 1400}
+
+	tests := []struct {
+		span Span
+		want Rect
+	}{
+		{Span{0, 0, 1, 1}, Rect{0, 0, 1146, 700}},
+		{Span{1, 0, 1, 1}, Rect{1146, 0, 2293, 700}},
+		{Span{2, 1, 1, 1}, Rect{2293, 700, 3440, 1400}},
+		{Span{0, 0, 3, 2}, Rect{0, 0, 3440, 1400}},
+		{Span{1, 0, 2, 2}, Rect{1146, 0, 3440, 1400}},
+	}
+
+	for _, tt := range tests {
+		if got := grid.Rect(area, tt.span); got != tt.want {
+			t.Errorf("Rect(%+v) = %+v, want %+v", tt.span, got, tt.want)
+		}
+	}
+}
+
+func TestGridCellAt(t *testing.T) {
+	grid := Grid{Columns: 3, Rows: 2}
+	area := Rect{-3440, 0, 0, 1440}
+
+	tests := []struct {
+		window Rect
+		want   Span
+	}{
+		{Rect{-3400, 100, -3000, 400}, Span{0, 0, 1, 1}},
+		{Rect{-2000, 800, -1400, 1200}, Span{1, 1, 1, 1}},
+		{Rect{-3440, 0, 0, 1440}, Span{1, 1, 1, 1}},
+		{Rect{-500, 1300, 300, 1700}, Span{2, 1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := grid.CellAt(area, tt.window); got != tt.want {
+			t.Errorf("CellAt(%+v) = %+v, want %+v", tt.window, got, tt.want)
+		}
+	}
+}
+
+func TestGridSpanOf(t *testing.T) {
+	grid := Grid{Columns: 2, Rows: 2}
+	area := Rect{0, 0, 1920, 1080}
+
+	tests := []struct {
+		name   string
+		window Rect
+		want   Span
+	}{
+		{"exact cell", Rect{960, 540, 1920, 1080}, Span{1, 1, 1, 1}},
+		{"left half with borders", Rect{-7, 0, 967, 1087}, Span{0, 0, 1, 2}},
+		{"small window", Rect{100, 100, 200, 200}, Span{0, 0, 1, 1}},
+		{"small window at the far edge", Rect{1800, 1000, 1900, 1070}, Span{1, 1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grid.SpanOf(area, tt.window); got != tt.want {
+				t.Errorf("SpanOf() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridMoveGrowShrink(t *testing.T) {
+	grid := Grid{Columns: 3, Rows: 2}
+
+	tests := []struct {
+		name string
+		got  Span
+		want Span
+	}{
+		{"move right", grid.Move(Span{0, 0, 1, 1}, Right), Span{1, 0, 1, 1}},
+		{"move right at the edge", grid.Move(Span{1, 0, 2, 1}, Right), Span{1, 0, 2, 1}},
+		{"move left at the edge", grid.Move(Span{0, 0, 1, 1}, Left), Span{0, 0, 1, 1}},
+		{"move down", grid.Move(Span{0, 0, 1, 1}, Down), Span{0, 1, 1, 1}},
+		{"move up", grid.Move(Span{0, 1, 1, 1}, Up), Span{0, 0, 1, 1}},
+		{"grow right", grid.Grow(Span{0, 0, 1, 1}, Right), Span{0, 0, 2, 1}},
+		{"grow left", grid.Grow(Span{1, 0, 1, 1}, Left), Span{0, 0, 2, 1}},
+		{"grow left at the edge", grid.Grow(Span{0, 0, 1, 1}, Left), Span{0, 0, 1, 1}},
+		{"grow down", grid.Grow(Span{0, 0, 1, 1}, Down), Span{0, 0, 1, 2}},
+		{"grow up at the edge", grid.Grow(Span{0, 0, 1, 1}, Up), Span{0, 0, 1, 1}},
+		{"shrink right", grid.Shrink(Span{0, 0, 3, 1}, Right), Span{0, 0, 2, 1}},
+		{"shrink left", grid.Shrink(Span{0, 0, 3, 1}, Left), Span{1, 0, 2, 1}},
+		{"shrink up", grid.Shrink(Span{0, 0, 1, 2}, Up), Span{0, 1, 1, 1}},
+		{"shrink single cell", grid.Shrink(Span{0, 0, 1, 1}, Down), Span{0, 0, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package placement
+
+// Layout kinds
+const (
+	// LayoutNone leaves the windows where they are
+	LayoutNone = ""
+	// LayoutMasterStack puts the first window on the left and stacks the others in rows on the right
+	LayoutMasterStack = "masterStack"
+	// LayoutColumns gives every window a column of equal width
+	LayoutColumns = "columns"
+	// LayoutRows gives every window a row of equal height
+	LayoutRows = "rows"
+	// LayoutBSP splits the remaining space in half for every window, alternating along the longer side
+	LayoutBSP = "bsp"
+)
+
+// Layout arranges the windows of a monitor
+type Layout struct {
+	Kind string `json:"kind"`
+	// MasterFraction is the fraction of the area covered by the master window in LayoutMasterStack, 0.5 if not set
+	MasterFraction float64 `json:"masterFraction"`
+}
+
+// Valid reports if the layout kind is known
+func (l Layout) Valid() bool {
+	switch l.Kind {
+	case LayoutNone, LayoutMasterStack, LayoutColumns, LayoutRows, LayoutBSP:
+		return true
+	}
+	return false
+}
+
+// Tile returns the rects of count windows arranged in the area, in the order of the windows.
+// It returns nil for LayoutNone and unknown layouts.
+func (l Layout) Tile(area Rect, count int) []Rect {
+	if count <= 0 {
+		return nil
+	}
+	switch l.Kind {
+	case LayoutMasterStack:
+		if count == 1 {
+			return []Rect{area}
+		}
+		fraction := l.MasterFraction
+		if fraction <= 0 || fraction >= 1 {
+			fraction = 0.5
+		}
+		master, _ := SplitFraction(area, Left, fraction)
+		stack := Rect{Left: master.Right, Top: area.Top, Right: area.Right, Bottom: area.Bottom}
+		return append([]Rect{master}, rows(stack, count-1)...)
+	case LayoutColumns:
+		return columns(area, count)
+	case LayoutRows:
+		return rows(area, count)
+	case LayoutBSP:
+		return bsp(area, count)
+	}
+	return nil
+}
+
+// columns divides the area into count columns sharing their edges
+func columns(area Rect, count int) []Rect {
+	rects := make([]Rect, count)
+	for i := range rects {
+		rects[i] = Rect{
+			Left:   area.Left + int32(int64(area.Width())*int64(i)/int64(count)),
+			Top:    area.Top,
+			Right:  area.Left + int32(int64(area.Width())*int64(i+1)/int64(count)),
+			Bottom: area.Bottom,
+		}
+	}
+	return rects
+}
+
+// rows divides the area into count rows sharing their edges
+func rows(area Rect, count int) []Rect {
+	rects := make([]Rect, count)
+	for i := range rects {
+		rects[i] = Rect{
+			Left:   area.Left,
+			Top:    area.Top + int32(int64(area.Height())*int64(i)/int64(count)),
+			Right:  area.Right,
+			Bottom: area.Top + int32(int64(area.Height())*int64(i+1)/int64(count)),
+		}
+	}
+	return rects
+}
+
+// bsp gives the first window one half of the area along its longer side and partitions the other half for the rest
+func bsp(area Rect, count int) []Rect {
+	if count == 1 {
+		return []Rect{area}
+	}
+	var first, rest Rect
+	if area.Width() >= area.Height() {
+		first, _ = Split(area, Left)
+		rest = Rect{Left: first.Right, Top: area.Top, Right: area.Right, Bottom: area.Bottom}
+	} else {
+		first, _ = Split(area, Up)
+		rest = Rect{Left: area.Left, Top: first.Bottom, Right: area.Right, Bottom: area.Bottom}
+	}
+	return append([]Rect{first}, bsp(rest, count-1)...)
+}
@@ -0,0 +1,72 @@
+package placement
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLayoutTile(t *testing.T) {
+	area := Rect{0, 0, 1920, 1080}
+	tests := []struct {
+		name   string
+		layout Layout
+		count  int
+		want   []Rect
+	}{
+		{"none", Layout{}, 2, nil},
+		{"unknown", Layout{Kind: "spiral"}, 2, nil},
+		{"no windows", Layout{Kind: LayoutColumns}, 0, nil},
+		{"master stack single window", Layout{Kind: LayoutMasterStack}, 1, []Rect{area}},
+		{"master stack", Layout{Kind: LayoutMasterStack, MasterFraction: 0.6}, 3, []Rect{
+			{0, 0, 1152, 1080},
+			{1152, 0, 1920, 540},
+			{1152, 540, 1920, 1080},
+		}},
+		{"master stack default fraction", Layout{Kind: LayoutMasterStack}, 2, []Rect{
+			{0, 0, 960, 1080},
+			{960, 0, 1920, 1080},
+		}},
+		{"columns", Layout{Kind: LayoutColumns}, 3, []Rect{
+			{0, 0, 640, 1080},
+			{640, 0, 1280, 1080},
+			{1280, 0, 1920, 1080},
+		}},
+		{"columns share uneven edges", Layout{Kind: LayoutColumns}, 7, []Rect{
+			{0, 0, 274, 1080},
+			{274, 0, 548, 1080},
+			{548, 0, 822, 1080},
+			{822, 0, 1097, 1080},
+			{1097, 0, 1371, 1080},
+			{1371, 0, 1645, 1080},
+			{1645, 0, 1920, 1080},
+		}},
+		{"rows", Layout{Kind: LayoutRows}, 2, []Rect{
+			{0, 0, 1920, 540},
+			{0, 540, 1920, 1080},
+		}},
+		{"bsp", Layout{Kind: LayoutBSP}, 4, []Rect{
+			{0, 0, 960, 1080},
+			{960, 0, 1920, 540},
+			{960, 540, 1440, 1080},
+			{1440, 540, 1920, 1080},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.layout.Tile(area, tt.count); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutTileOffsetArea(t *testing.T) {
+	// Monitors left of or above the primary monitor have negative coordinates
+	area := Rect{-1920, -40, 0, 1040}
+	got := Layout{Kind: LayoutColumns}.Tile(area, 2)
+	want := []Rect{{-1920, -40, -960, 1040}, {-960, -40, 0, 1040}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tile() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package placement
+
+// perpendicularPenalty weighs the perpendicular distance against the distance in the direction,
+// so a window straight in the direction wins over a closer window off to the side
+const perpendicularPenalty = 2
+
+// NearestInDirection returns the index of the candidate nearest to the rect in the direction, -1 if there is none.
+// Candidates in the same row or column (sharing part of the perpendicular extent) only have to lie beyond the center of the rect,
+// other candidates have to lie within 45 degrees of the direction and get a penalty for their perpendicular distance.
+// The score is the distance between the centers in the direction plus the penalty, the lowest score wins.
+// Ties go to the earlier candidate, e.g. the topmost window in z-order.
+func NearestInDirection(from Rect, candidates []Rect, direction int) int {
+	best := -1
+	var bestScore int64
+	for i, candidate := range candidates {
+		along, offset, ok := directionDistance(from, candidate, direction)
+		if !ok {
+			continue
+		}
+		score := along + perpendicularPenalty*offset
+		if best < 0 || score < bestScore {
+			best = i
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// directionDistance returns the distance between the centers of the rects in the direction and the perpendicular offset
+// of the other rect, 0 if the rects share part of the perpendicular extent. Both are doubled to avoid rounding the centers.
+// It returns false if the center of the other rect is not beyond the center of the rect or more off to the side than in the direction.
+func directionDistance(from, other Rect, direction int) (along, offset int64, ok bool) {
+	fromX, fromY := int64(from.Left)+int64(from.Right), int64(from.Top)+int64(from.Bottom)
+	otherX, otherY := int64(other.Left)+int64(other.Right), int64(other.Top)+int64(other.Bottom)
+	switch direction {
+	case Left, Right:
+		along = otherX - fromX
+		if direction == Left {
+			along = -along
+		}
+		if min(from.Bottom, other.Bottom) <= max(from.Top, other.Top) {
+			offset = abs64(otherY - fromY)
+		}
+	case Up, Down:
+		along = otherY - fromY
+		if direction == Up {
+			along = -along
+		}
+		if min(from.Right, other.Right) <= max(from.Left, other.Left) {
+			offset = abs64(otherX - fromX)
+		}
+	default:
+		return 0, 0, false
+	}
+	return along, offset, along > 0 && offset <= along
+}
+
+func abs64(a int64) int64 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,38 @@
+package placement
+
+import "testing"
+
+func TestNearestInDirection(t *testing.T) {
+	// Two monitors side by side, the left one split into a left half and two right quarters
+	leftHalf := Rect{0, 0, 960, 1040}
+	topRight := Rect{960, 0, 1920, 520}
+	bottomRight := Rect{960, 520, 1920, 1040}
+	secondMonitor := Rect{1920, 0, 3840, 1040}
+
+	tests := []struct {
+		name       string
+		from       Rect
+		candidates []Rect
+		direction  int
+		want       int
+	}{
+		{"tie goes to the topmost window", leftHalf, []Rect{topRight, bottomRight, secondMonitor}, Right, 0},
+		{"across monitors", topRight, []Rect{leftHalf, bottomRight, secondMonitor}, Right, 2},
+		{"down within the column", topRight, []Rect{leftHalf, bottomRight, secondMonitor}, Down, 1},
+		{"back from the other monitor", secondMonitor, []Rect{leftHalf, bottomRight, topRight}, Left, 1},
+		{"nothing above", topRight, []Rect{leftHalf, bottomRight, secondMonitor}, Up, -1},
+		{"window off to the side is not above", secondMonitor, []Rect{topRight}, Up, -1},
+		{"same row wins over a closer window off to the side", Rect{0, 0, 500, 500}, []Rect{{600, 2000, 800, 2200}, {1500, 0, 1900, 500}}, Right, 1},
+		{"overlapping window beyond the center", Rect{0, 0, 1000, 1000}, []Rect{{200, 100, 1200, 900}}, Right, 0},
+		{"no candidates", leftHalf, nil, Right, -1},
+		{"invalid direction", leftHalf, []Rect{topRight}, 3, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NearestInDirection(tt.from, tt.candidates, tt.direction); got != tt.want {
+				t.Errorf("NearestInDirection() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+// Package placement contains the platform independent geometry used to
+// position windows on monitors. It has no Win32 dependencies so the math can
+// be tested on any OS.
+package placement
+
+// Rect has the same layout as the Win32 RECT structure
+type Rect struct {
+	Left, Top, Right, Bottom int32
+}
+
+func (r Rect) Width() int32 {
+	return r.Right - r.Left
+}
+
+func (r Rect) Height() int32 {
+	return r.Bottom - r.Top
+}
+
+// Monitor describes the geometry of a monitor
+type Monitor struct {
+	Bounds Rect
+	// WorkArea is the part of the monitor not covered by the taskbar or docked toolbars
+	WorkArea Rect
+	// DPI is the effective DPI of the monitor, 96 is 100% scaling and 0 is unknown
+	DPI uint32
+}
+
+// DPIScale returns the factor to scale pixel sizes with when moving from the source to the target monitor
+func DPIScale(source, target Monitor) float64 {
+	if source.DPI == 0 || target.DPI == 0 {
+		return 1
+	}
+	return float64(target.DPI) / float64(source.DPI)
+}
+
+// Area returns the rect of the monitor windows are placed in
+func (m Monitor) Area(useWorkArea bool) Rect {
+	if useWorkArea && m.WorkArea.Width() > 0 && m.WorkArea.Height() > 0 {
+		return m.WorkArea
+	}
+	return m.Bounds
+}
+
+// Mode controls how a window is resized when the resolution of the monitors is different
+type Mode int
+
+const (
+	// Percentage keeps the window size and position relative to the monitor size
+	Percentage Mode = iota
+	// SizeByPixel keeps the window size and offset in pixels
+	SizeByPixel
+)
+
+// Options controls how windows are placed
+type Options struct {
+	Mode Mode
+	// UseWorkArea places windows relative to the work area instead of the full monitor
+	UseWorkArea bool
+	// ScaleByDPI scales pixel sizes by the ratio of the monitor DPIs in SizeByPixel mode,
+	// so the window keeps its physical size
+	ScaleByDPI bool
+	// Clamp controls how a moved window is kept within the target monitor
+	Clamp ClampPolicy
+}
+
+// ClampPolicy controls how a window is kept within an area
+type ClampPolicy int
+
+const (
+	// ClampNone leaves the window as it is, it may hang off the area
+	ClampNone ClampPolicy = iota
+	// ClampShift moves the window into the area without resizing it
+	ClampShift
+	// ClampShrink shrinks the window to the size of the area if needed and moves it into the area
+	ClampShrink
+)
+
+// Directions
+const (
+	Left  = -1
+	Right = 1
+	Up    = -2
+	Down  = 2
+)
+
+// Move calculates the new rect of a window moved from the source to the target monitor
+func Move(window Rect, source, target Monitor, opts Options) Rect {
+	return Clamp(move(window, source, target, opts), target.Area(opts.UseWorkArea), opts.Clamp)
+}
+
+func move(window Rect, source, target Monitor, opts Options) Rect {
+	sourceArea := source.Area(opts.UseWorkArea)
+	targetArea := target.Area(opts.UseWorkArea)
+
+	if opts.Mode == SizeByPixel {
+		scale := 1.0
+		if opts.ScaleByDPI {
+			scale = DPIScale(source, target)
+		}
+
+		// Calculate the window's current size and position pixel based
+		newWidth := int32(float64(window.Width()) * scale)
+		newHeight := int32(float64(window.Height()) * scale)
+
+		relativeX := int32(float64(window.Left-sourceArea.Left) * scale)
+		relativeY := int32(float64(window.Top-sourceArea.Top) * scale)
+
+		// Pixel based calculation
+		newX := targetArea.Left + relativeX
+		newY := targetArea.Top + relativeY
+		return Rect{Left: newX, Top: newY, Right: newX + newWidth, Bottom: newY + newHeight}
+	}
+
+	// Calculate the percentage of the window's size relative to the current monitor
+	sourceWidth := float64(sourceArea.Width())
+	sourceHeight := float64(sourceArea.Height())
+	if sourceWidth <= 0 || sourceHeight <= 0 {
+		return window
+	}
+
+	widthPercentage := float64(window.Width()) / sourceWidth
+	heightPercentage := float64(window.Height()) / sourceHeight
+
+	// Calculate the new size based on the target monitor's dimensions
+	targetWidth := float64(targetArea.Width())
+	targetHeight := float64(targetArea.Height())
+
+	newWidth := int32(widthPercentage * targetWidth)
+	newHeight := int32(heightPercentage * targetHeight)
+
+	// Calculate the new position
+	relativeXPercentage := float64(window.Left-sourceArea.Left) / sourceWidth
+	relativeYPercentage := float64(window.Top-sourceArea.Top) / sourceHeight
+
+	// Percentage based calculation
+	newX := targetArea.Left + int32(relativeXPercentage*targetWidth)
+	newY := targetArea.Top + int32(relativeYPercentage*targetHeight)
+	return Rect{Left: newX, Top: newY, Right: newX + newWidth, Bottom: newY + newHeight}
+}
+
+// Clamp keeps the rect within the area according to the policy.
+// With ClampShift a rect larger than the area is aligned to the top-left corner of the area.
+func Clamp(r, area Rect, policy ClampPolicy) Rect {
+	if policy == ClampNone {
+		return r
+	}
+
+	width := r.Width()
+	height := r.Height()
+	if policy == ClampShrink {
+		width = min(width, area.Width())
+		height = min(height, area.Height())
+	}
+
+	left := max(min(r.Left, area.Right-width), area.Left)
+	top := max(min(r.Top, area.Bottom-height), area.Top)
+	return Rect{Left: left, Top: top, Right: left + width, Bottom: top + height}
+}
+
+// snapTolerance is the number of pixels a window edge may be off to still count as snapped
+const snapTolerance = 10
+
+// Split calculates the rect of a window snapped to the half of the area in the given direction
+func Split(area Rect, direction int) (Rect, bool) {
+	return SplitFraction(area, direction, 0.5)
+}
+
+// SplitFraction calculates the rect of a window snapped to the edge of the area in the given direction,
+// covering the given fraction of the area
+func SplitFraction(area Rect, direction int, fraction float64) (Rect, bool) {
+	if fraction <= 0 || fraction > 1 {
+		return Rect{}, false
+	}
+	width := float64(area.Width())
+	height := float64(area.Height())
+
+	// Right and down splits are measured from the opposite edge so that complementary splits share a seam
+	switch direction {
+	case Left:
+		return Rect{Left: area.Left, Top: area.Top, Right: area.Left + int32(width*fraction), Bottom: area.Bottom}, true
+	case Right:
+		return Rect{Left: area.Left + int32(width*(1-fraction)), Top: area.Top, Right: area.Right, Bottom: area.Bottom}, true
+	case Up:
+		return Rect{Left: area.Left, Top: area.Top, Right: area.Right, Bottom: area.Top + int32(height*fraction)}, true
+	case Down:
+		return Rect{Left: area.Left, Top: area.Top + int32(height*(1-fraction)), Right: area.Right, Bottom: area.Bottom}, true
+	}
+	return Rect{}, false
+}
+
+// NextSplitFraction returns the fraction to split the window to when a split in the given direction is requested.
+// If the window is already snapped to one of the fractions the next fraction in the cycle is returned, otherwise the first one.
+func NextSplitFraction(window, area Rect, direction int, fractions []float64) float64 {
+	if len(fractions) == 0 {
+		return 0.5
+	}
+	for i, fraction := range fractions {
+		snapped, ok := SplitFraction(area, direction, fraction)
+		if ok && nearlyEqual(window, snapped, snapTolerance) {
+			return fractions[(i+1)%len(fractions)]
+		}
+	}
+	return fractions[0]
+}
+
+// Quarter calculates the rect of a window snapped to the corner of the area given by a horizontal and a vertical direction
+func Quarter(area Rect, horizontal, vertical int) (Rect, bool) {
+	if (horizontal != Left && horizontal != Right) || (vertical != Up && vertical != Down) {
+		return Rect{}, false
+	}
+	h, _ := Split(area, horizontal)
+	v, _ := Split(area, vertical)
+	return Rect{Left: h.Left, Top: v.Top, Right: h.Right, Bottom: v.Bottom}, true
+}
+
+// CombineSplit splits a window that is already snapped to an edge of the area in the perpendicular direction,
+// e.g. a window on the left half split up ends up in the top-left quarter.
+// It returns false if the window is not snapped perpendicular to the direction or the split would not change the window.
+func CombineSplit(window, area Rect, direction int) (Rect, bool) {
+	half, ok := Split(area, direction)
+	if !ok {
+		return Rect{}, false
+	}
+
+	near := func(a, b int32) bool {
+		return abs(a-b) <= snapTolerance
+	}
+	leftOrRight := near(window.Left, area.Left) || near(window.Right, area.Right)
+	fullWidth := near(window.Left, area.Left) && near(window.Right, area.Right)
+	fullHeight := near(window.Top, area.Top) && near(window.Bottom, area.Bottom)
+	topOrBottom := near(window.Top, area.Top) || near(window.Bottom, area.Bottom)
+	// A quarter fills half of the area on both axes
+	top, _ := Split(area, Up)
+	left, _ := Split(area, Left)
+	halfHeight := (near(window.Top, area.Top) && near(window.Bottom, top.Bottom)) ||
+		(near(window.Top, top.Bottom) && near(window.Bottom, area.Bottom))
+	halfWidth := (near(window.Left, area.Left) && near(window.Right, left.Right)) ||
+		(near(window.Left, left.Right) && near(window.Right, area.Right))
+
+	// The window has to fill a split: the full extent or a half along the direction, and one edge on the other axis
+	var combined Rect
+	switch direction {
+	case Up, Down:
+		if !leftOrRight || fullWidth || !(fullHeight || halfHeight) {
+			return Rect{}, false
+		}
+		combined = Rect{Left: window.Left, Top: half.Top, Right: window.Right, Bottom: half.Bottom}
+	case Left, Right:
+		if !topOrBottom || fullHeight || !(fullWidth || halfWidth) {
+			return Rect{}, false
+		}
+		combined = Rect{Left: half.Left, Top: window.Top, Right: half.Right, Bottom: window.Bottom}
+	}
+
+	if nearlyEqual(window, combined, snapTolerance) {
+		return Rect{}, false
+	}
+	return combined, true
+}
+
+// Snapped reports if the window is already at the target rect, tolerating small differences from rounding
+func Snapped(window, target Rect) bool {
+	return nearlyEqual(window, target, snapTolerance)
+}
+
+// nearlyEqual checks if all edges of the rects are within the tolerance
+func nearlyEqual(a, b Rect, tolerance int32) bool {
+	return abs(a.Left-b.Left) <= tolerance &&
+		abs(a.Top-b.Top) <= tolerance &&
+		abs(a.Right-b.Right) <= tolerance &&
+		abs(a.Bottom-b.Bottom) <= tolerance
+}
+
+func abs(a int32) int32 {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Shrink makes the rect smaller by the given fraction and moves it so it stays roughly centered.
+// Used when a maximized window is restored before being moved.
+func Shrink(r Rect, amount float64) Rect {
+	newWidth := int32(float64(r.Width()) * (1 - amount))
+	newHeight := int32(float64(r.Height()) * (1 - amount))
+	newX := r.Left + int32(float64(newWidth)*amount/2)
+	newY := r.Top + int32(float64(newHeight)*amount/2)
+	return Rect{Left: newX, Top: newY, Right: newX + newWidth, Bottom: newY + newHeight}
+}
@@ -0,0 +1,452 @@
+package placement
+
+import "testing"
+
+func monitor(left, top, right, bottom int32) Monitor {
+	return Monitor{Bounds: Rect{Left: left, Top: top, Right: right, Bottom: bottom}}
+}
+
+func TestMove(t *testing.T) {
+	fullHD := monitor(0, 0, 1920, 1080)
+	fullHDRight := monitor(1920, 0, 3840, 1080)
+	fullHDLeft := monitor(-1920, 0, 0, 1080)
+	fourKRight := monitor(1920, 0, 5760, 2160)
+	fourKAbove := monitor(0, -2160, 3840, 0)
+	portraitRight := monitor(1920, -420, 3000, 1500)
+
+	tests := []struct {
+		name   string
+		window Rect
+		source Monitor
+		target Monitor
+		opts   Options
+		want   Rect
+	}{
+		{
+			name:   "same resolution percentage",
+			window: Rect{100, 100, 1060, 640},
+			source: fullHD,
+			target: fullHDRight,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{2020, 100, 2980, 640},
+		},
+		{
+			name:   "same resolution pixel",
+			window: Rect{100, 100, 1060, 640},
+			source: fullHD,
+			target: fullHDRight,
+			opts:   Options{Mode: SizeByPixel},
+			want:   Rect{2020, 100, 2980, 640},
+		},
+		{
+			name:   "negative coordinates",
+			window: Rect{-1820, 50, -860, 590},
+			source: fullHDLeft,
+			target: fullHD,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{100, 50, 1060, 590},
+		},
+		{
+			name:   "full hd to 4k percentage",
+			window: Rect{0, 0, 960, 540},
+			source: fullHD,
+			target: fourKRight,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{1920, 0, 3840, 1080},
+		},
+		{
+			name:   "full hd to 4k pixel",
+			window: Rect{0, 0, 960, 540},
+			source: fullHD,
+			target: fourKRight,
+			opts:   Options{Mode: SizeByPixel},
+			want:   Rect{1920, 0, 2880, 540},
+		},
+		{
+			name:   "4k to full hd percentage",
+			window: Rect{960, -1080, 2880, 0},
+			source: fourKAbove,
+			target: fullHD,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{480, 540, 1440, 1080},
+		},
+		{
+			name:   "landscape to portrait percentage",
+			window: Rect{0, 0, 1920, 540},
+			source: fullHD,
+			target: portraitRight,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{1920, -420, 3000, 540},
+		},
+		{
+			name:   "landscape to portrait pixel",
+			window: Rect{480, 270, 1440, 810},
+			source: fullHD,
+			target: portraitRight,
+			opts:   Options{Mode: SizeByPixel},
+			want:   Rect{2400, -150, 3360, 390},
+		},
+		{
+			name:   "empty source monitor",
+			window: Rect{0, 0, 100, 100},
+			source: Monitor{},
+			target: fullHD,
+			opts:   Options{Mode: Percentage},
+			want:   Rect{0, 0, 100, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Move(tt.window, tt.source, tt.target, tt.opts)
+			if got != tt.want {
+				t.Errorf("Move() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveWorkArea(t *testing.T) {
+	// Taskbar at the bottom of the left monitor and on the left edge of the right monitor
+	source := Monitor{
+		Bounds:   Rect{0, 0, 1920, 1080},
+		WorkArea: Rect{0, 0, 1920, 1040},
+	}
+	target := Monitor{
+		Bounds:   Rect{1920, 0, 3840, 1080},
+		WorkArea: Rect{2020, 0, 3840, 1080},
+	}
+
+	tests := []struct {
+		name   string
+		window Rect
+		opts   Options
+		want   Rect
+	}{
+		{
+			name:   "left half percentage",
+			window: Rect{0, 0, 960, 1040},
+			opts:   Options{Mode: Percentage, UseWorkArea: true},
+			want:   Rect{2020, 0, 2930, 1080},
+		},
+		{
+			name:   "left half pixel",
+			window: Rect{0, 0, 960, 1040},
+			opts:   Options{Mode: SizeByPixel, UseWorkArea: true},
+			want:   Rect{2020, 0, 2980, 1040},
+		},
+		{
+			name:   "full monitor percentage",
+			window: Rect{0, 0, 960, 1040},
+			opts:   Options{Mode: Percentage, UseWorkArea: false},
+			want:   Rect{1920, 0, 2880, 1040},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Move(tt.window, source, target, tt.opts)
+			if got != tt.want {
+				t.Errorf("Move() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveScaleByDPI(t *testing.T) {
+	laptop := Monitor{Bounds: Rect{0, 0, 2880, 1800}, DPI: 144}
+	external := Monitor{Bounds: Rect{2880, 0, 4800, 1080}, DPI: 96}
+	unknown := Monitor{Bounds: Rect{-1920, 0, 0, 1080}}
+
+	tests := []struct {
+		name   string
+		window Rect
+		source Monitor
+		target Monitor
+		opts   Options
+		want   Rect
+	}{
+		{
+			name:   "150% to 100%",
+			window: Rect{300, 150, 1800, 1050},
+			source: laptop,
+			target: external,
+			opts:   Options{Mode: SizeByPixel, ScaleByDPI: true},
+			want:   Rect{3080, 100, 4080, 700},
+		},
+		{
+			name:   "100% to 150%",
+			window: Rect{3080, 100, 4080, 700},
+			source: external,
+			target: laptop,
+			opts:   Options{Mode: SizeByPixel, ScaleByDPI: true},
+			want:   Rect{300, 150, 1800, 1050},
+		},
+		{
+			name:   "scaling disabled",
+			window: Rect{300, 150, 1800, 1050},
+			source: laptop,
+			target: external,
+			opts:   Options{Mode: SizeByPixel},
+			want:   Rect{3180, 150, 4680, 1050},
+		},
+		{
+			name:   "unknown DPI is not scaled",
+			window: Rect{300, 150, 1800, 1050},
+			source: laptop,
+			target: unknown,
+			opts:   Options{Mode: SizeByPixel, ScaleByDPI: true},
+			want:   Rect{-1620, 150, -120, 1050},
+		},
+		{
+			name:   "percentage mode ignores DPI",
+			window: Rect{0, 0, 1440, 900},
+			source: laptop,
+			target: external,
+			opts:   Options{Mode: Percentage, ScaleByDPI: true},
+			want:   Rect{2880, 0, 3840, 540},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Move(tt.window, tt.source, tt.target, tt.opts)
+			if got != tt.want {
+				t.Errorf("Move() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDPIScale(t *testing.T) {
+	tests := []struct {
+		source, target uint32
+		want           float64
+	}{
+		{96, 96, 1},
+		{144, 96, 96.0 / 144.0},
+		{96, 192, 2},
+		{0, 96, 1},
+		{96, 0, 1},
+	}
+
+	for _, tt := range tests {
+		got := DPIScale(Monitor{DPI: tt.source}, Monitor{DPI: tt.target})
+		if got != tt.want {
+			t.Errorf("DPIScale(%d, %d) = %v, want %v", tt.source, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestClamp(t *testing.T) {
+	area := Rect{1920, 0, 3840, 1080}
+
+	tests := []struct {
+		name   string
+		r      Rect
+		policy ClampPolicy
+		want   Rect
+	}{
+		{"inside", Rect{2000, 100, 3000, 600}, ClampShrink, Rect{2000, 100, 3000, 600}},
+		{"off the right edge shrink", Rect{2020, 100, 4420, 700}, ClampShrink, Rect{1920, 100, 3840, 700}},
+		{"off the right edge shift", Rect{3000, 100, 4000, 700}, ClampShift, Rect{2840, 100, 3840, 700}},
+		{"too wide shift", Rect{2020, 100, 4420, 700}, ClampShift, Rect{1920, 100, 4320, 700}},
+		{"off the top left", Rect{1800, -50, 2200, 300}, ClampShift, Rect{1920, 0, 2320, 350}},
+		{"too large shrink", Rect{0, -100, 5000, 2000}, ClampShrink, Rect{1920, 0, 3840, 1080}},
+		{"none", Rect{2020, 100, 4420, 700}, ClampNone, Rect{2020, 100, 4420, 700}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clamp(tt.r, area, tt.policy); got != tt.want {
+				t.Errorf("Clamp() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveClamp(t *testing.T) {
+	source := monitor(0, 0, 2560, 1440)
+	target := monitor(2560, 0, 4480, 1080)
+	window := Rect{100, 100, 2500, 1300}
+
+	tests := []struct {
+		name string
+		opts Options
+		want Rect
+	}{
+		{"pixel shrink", Options{Mode: SizeByPixel, Clamp: ClampShrink}, Rect{2560, 0, 4480, 1080}},
+		{"pixel shift", Options{Mode: SizeByPixel, Clamp: ClampShift}, Rect{2560, 0, 4960, 1200}},
+		{"pixel none", Options{Mode: SizeByPixel, Clamp: ClampNone}, Rect{2660, 100, 5060, 1300}},
+		{"percentage fits already", Options{Mode: Percentage, Clamp: ClampShrink}, Rect{2635, 75, 4435, 975}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Move(window, source, target, tt.opts)
+			if got != tt.want {
+				t.Errorf("Move() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArea(t *testing.T) {
+	m := Monitor{Bounds: Rect{0, 0, 1920, 1080}, WorkArea: Rect{0, 40, 1920, 1080}}
+	if got := m.Area(true); got != m.WorkArea {
+		t.Errorf("Area(true) = %+v, want %+v", got, m.WorkArea)
+	}
+	if got := m.Area(false); got != m.Bounds {
+		t.Errorf("Area(false) = %+v, want %+v", got, m.Bounds)
+	}
+
+	// Monitors without a work area fall back to the bounds
+	m = Monitor{Bounds: Rect{0, 0, 1920, 1080}}
+	if got := m.Area(true); got != m.Bounds {
+		t.Errorf("Area(true) without work area = %+v, want %+v", got, m.Bounds)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	area := Rect{100, 0, 1921, 1040}
+	tests := []struct {
+		direction int
+		want      Rect
+		ok        bool
+	}{
+		{Left, Rect{100, 0, 1010, 1040}, true},
+		{Right, Rect{1010, 0, 1921, 1040}, true},
+		{Up, Rect{100, 0, 1921, 520}, true},
+		{Down, Rect{100, 520, 1921, 1040}, true},
+		{0, Rect{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Split(area, tt.direction)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Split(%d) = %+v, %v, want %+v, %v", tt.direction, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSplitFraction(t *testing.T) {
+	area := Rect{0, 0, 1920, 1080}
+	tests := []struct {
+		direction int
+		fraction  float64
+		want      Rect
+		ok        bool
+	}{
+		{Left, 0.3333, Rect{0, 0, 639, 1080}, true},
+		{Right, 0.3333, Rect{1280, 0, 1920, 1080}, true},
+		{Left, 0.6667, Rect{0, 0, 1280, 1080}, true},
+		{Down, 0.25, Rect{0, 810, 1920, 1080}, true},
+		{Up, 1, Rect{0, 0, 1920, 1080}, true},
+		{Left, 0, Rect{}, false},
+		{Left, 1.5, Rect{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := SplitFraction(area, tt.direction, tt.fraction)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("SplitFraction(%d, %v) = %+v, %v, want %+v, %v", tt.direction, tt.fraction, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestNextSplitFraction(t *testing.T) {
+	area := Rect{0, 0, 1920, 1040}
+	cycle := []float64{0.5, 0.3333, 0.6667}
+
+	tests := []struct {
+		name      string
+		window    Rect
+		direction int
+		fractions []float64
+		want      float64
+	}{
+		{"floating window starts the cycle", Rect{100, 100, 900, 700}, Left, cycle, 0.5},
+		{"half goes to third", Rect{0, 0, 960, 1040}, Left, cycle, 0.3333},
+		{"third goes to two thirds", Rect{0, 0, 639, 1040}, Left, cycle, 0.6667},
+		{"two thirds wraps to half", Rect{0, 0, 1280, 1040}, Left, cycle, 0.5},
+		{"invisible borders are tolerated", Rect{-7, 0, 967, 1047}, Left, cycle, 0.3333},
+		{"left half is not a right split", Rect{0, 0, 960, 1040}, Right, cycle, 0.5},
+		{"right half goes to third", Rect{960, 0, 1920, 1040}, Right, cycle, 0.3333},
+		{"no cycle configured", Rect{0, 0, 960, 1040}, Left, nil, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextSplitFraction(tt.window, area, tt.direction, tt.fractions)
+			if got != tt.want {
+				t.Errorf("NextSplitFraction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuarter(t *testing.T) {
+	area := Rect{0, 0, 3840, 2160}
+	tests := []struct {
+		horizontal int
+		vertical   int
+		want       Rect
+		ok         bool
+	}{
+		{Left, Up, Rect{0, 0, 1920, 1080}, true},
+		{Right, Up, Rect{1920, 0, 3840, 1080}, true},
+		{Left, Down, Rect{0, 1080, 1920, 2160}, true},
+		{Right, Down, Rect{1920, 1080, 3840, 2160}, true},
+		{Up, Left, Rect{}, false},
+		{Left, Left, Rect{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Quarter(area, tt.horizontal, tt.vertical)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Quarter(%d, %d) = %+v, %v, want %+v, %v", tt.horizontal, tt.vertical, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCombineSplit(t *testing.T) {
+	area := Rect{0, 0, 3840, 2160}
+	tests := []struct {
+		name      string
+		window    Rect
+		direction int
+		want      Rect
+		ok        bool
+	}{
+		{"left half up", Rect{0, 0, 1920, 2160}, Up, Rect{0, 0, 1920, 1080}, true},
+		{"right half down", Rect{1920, 0, 3840, 2160}, Down, Rect{1920, 1080, 3840, 2160}, true},
+		{"left third up keeps the width", Rect{0, 0, 1280, 2160}, Up, Rect{0, 0, 1280, 1080}, true},
+		{"top half left", Rect{0, 0, 3840, 1080}, Left, Rect{0, 0, 1920, 1080}, true},
+		{"top left quarter down", Rect{0, 0, 1920, 1080}, Down, Rect{0, 1080, 1920, 2160}, true},
+		{"top left quarter right", Rect{0, 0, 1920, 1080}, Right, Rect{1920, 0, 3840, 1080}, true},
+		{"top left quarter up is unchanged", Rect{0, 0, 1920, 1080}, Up, Rect{}, false},
+		{"left half left is not perpendicular", Rect{0, 0, 1920, 2160}, Left, Rect{}, false},
+		{"top half up is not perpendicular", Rect{0, 0, 3840, 1080}, Up, Rect{}, false},
+		{"floating window", Rect{200, 200, 1200, 900}, Up, Rect{}, false},
+		{"floating window in the corner", Rect{0, 0, 800, 600}, Left, Rect{}, false},
+		{"floating window in the corner up", Rect{0, 0, 800, 600}, Up, Rect{}, false},
+		{"invalid direction", Rect{0, 0, 1920, 2160}, 0, Rect{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CombineSplit(tt.window, area, tt.direction)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("CombineSplit() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestShrink(t *testing.T) {
+	got := Shrink(Rect{0, 0, 1000, 500}, 0.02)
+	want := Rect{9, 4, 989, 494}
+	if got != want {
+		t.Errorf("Shrink() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package placement
+
+// minStepSize is the smallest width or height a window is shrunk to by ResizeEdge
+const minStepSize = 100
+
+// Step is the distance windows are nudged or resized by
+type Step struct {
+	// Pixels is the step in pixels, used if Percent is not set
+	Pixels int32 `json:"pixels"`
+	// Percent is the step in percent of the area width (left, right) or height (up, down)
+	Percent float64 `json:"percent"`
+}
+
+// Size returns the step in pixels for the direction within the area
+func (s Step) Size(area Rect, direction int) int32 {
+	if s.Percent <= 0 {
+		return s.Pixels
+	}
+	switch direction {
+	case Left, Right:
+		return int32(float64(area.Width()) * s.Percent / 100)
+	case Up, Down:
+		return int32(float64(area.Height()) * s.Percent / 100)
+	}
+	return 0
+}
+
+// Center centers the window in the area, shrinking it if it is larger than the area
+func Center(window, area Rect) Rect {
+	width := min(window.Width(), area.Width())
+	height := min(window.Height(), area.Height())
+	left := area.Left + (area.Width()-width)/2
+	top := area.Top + (area.Height()-height)/2
+	return Rect{Left: left, Top: top, Right: left + width, Bottom: top + height}
+}
+
+// Nudge moves the window by step pixels in the direction, keeping it within the area
+func Nudge(window, area Rect, direction int, step int32) Rect {
+	var dx, dy int32
+	switch direction {
+	case Left:
+		dx = -step
+	case Right:
+		dx = step
+	case Up:
+		dy = -step
+	case Down:
+		dy = step
+	}
+	moved := Rect{Left: window.Left + dx, Top: window.Top + dy, Right: window.Right + dx, Bottom: window.Bottom + dy}
+	return Clamp(moved, area, ClampShrink)
+}
+
+// ResizeEdge moves the edge of the window on the side of the direction outwards by step pixels (inwards for a negative step).
+// The edge stays within the area and the window keeps a minimum size.
+func ResizeEdge(window, area Rect, direction int, step int32) Rect {
+	r := window
+	switch direction {
+	case Left:
+		r.Left = max(r.Left-step, area.Left)
+		r.Left = min(r.Left, r.Right-min(minStepSize, window.Width()))
+	case Right:
+		r.Right = min(r.Right+step, area.Right)
+		r.Right = max(r.Right, r.Left+min(minStepSize, window.Width()))
+	case Up:
+		r.Top = max(r.Top-step, area.Top)
+		r.Top = min(r.Top, r.Bottom-min(minStepSize, window.Height()))
+	case Down:
+		r.Bottom = min(r.Bottom+step, area.Bottom)
+		r.Bottom = max(r.Bottom, r.Top+min(minStepSize, window.Height()))
+	}
+	return r
+}
@@ -0,0 +1,98 @@
+package placement
+
+import "testing"
+
+func TestStepSize(t *testing.T) {
+	area := Rect{0, 0, 1920, 1040}
+	tests := []struct {
+		name      string
+		step      Step
+		direction int
+		want      int32
+	}{
+		{"pixels", Step{Pixels: 50}, Left, 50},
+		{"percent of the width", Step{Pixels: 50, Percent: 5}, Right, 96},
+		{"percent of the height", Step{Percent: 5}, Down, 52},
+		{"invalid direction", Step{Percent: 5}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.Size(area, tt.direction); got != tt.want {
+				t.Errorf("Size() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCenter(t *testing.T) {
+	area := Rect{1920, 0, 3840, 1040}
+	tests := []struct {
+		name   string
+		window Rect
+		want   Rect
+	}{
+		{"smaller window", Rect{0, 0, 960, 520}, Rect{2400, 260, 3360, 780}},
+		{"larger window", Rect{0, 0, 2560, 1440}, Rect{1920, 0, 3840, 1040}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Center(tt.window, area); got != tt.want {
+				t.Errorf("Center() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNudge(t *testing.T) {
+	area := Rect{0, 0, 1920, 1040}
+	tests := []struct {
+		name      string
+		window    Rect
+		direction int
+		want      Rect
+	}{
+		{"left", Rect{100, 100, 500, 400}, Left, Rect{50, 100, 450, 400}},
+		{"right", Rect{100, 100, 500, 400}, Right, Rect{150, 100, 550, 400}},
+		{"up", Rect{100, 100, 500, 400}, Up, Rect{100, 50, 500, 350}},
+		{"down", Rect{100, 100, 500, 400}, Down, Rect{100, 150, 500, 450}},
+		{"stops at the edge", Rect{20, 100, 420, 400}, Left, Rect{0, 100, 400, 400}},
+		{"stays at the edge", Rect{1520, 100, 1920, 400}, Right, Rect{1520, 100, 1920, 400}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Nudge(tt.window, area, tt.direction, 50); got != tt.want {
+				t.Errorf("Nudge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResizeEdge(t *testing.T) {
+	area := Rect{0, 0, 1920, 1040}
+	tests := []struct {
+		name      string
+		window    Rect
+		direction int
+		step      int32
+		want      Rect
+	}{
+		{"grow left", Rect{100, 100, 500, 400}, Left, 50, Rect{50, 100, 500, 400}},
+		{"grow down", Rect{100, 100, 500, 400}, Down, 50, Rect{100, 100, 500, 450}},
+		{"shrink right", Rect{100, 100, 500, 400}, Right, -50, Rect{100, 100, 450, 400}},
+		{"shrink up", Rect{100, 100, 500, 400}, Up, -50, Rect{100, 150, 500, 400}},
+		{"grow stops at the area", Rect{20, 100, 500, 400}, Left, 50, Rect{0, 100, 500, 400}},
+		{"shrink keeps a minimum size", Rect{100, 100, 220, 400}, Right, -50, Rect{100, 100, 200, 400}},
+		{"small windows are not grown by shrinking", Rect{100, 100, 150, 400}, Left, -50, Rect{100, 100, 150, 400}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResizeEdge(tt.window, area, tt.direction, tt.step); got != tt.want {
+				t.Errorf("ResizeEdge() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Exclusion keeps the hotkey actions away from the matching windows
+type Exclusion struct {
+	Match
+	// AllowMove lets actions move the window as long as they do not resize it
+	AllowMove bool `json:"allowMove"`
+}
+
+// compiledExclusion is an exclusion with its match compiled
+type compiledExclusion struct {
+	Exclusion
+	matcher matcher
+}
+
+// Exclusions holds the exclusions in order, the first matching exclusion applies
+type Exclusions struct {
+	exclusions []compiledExclusion
+}
+
+// NewExclusions compiles the exclusions. Invalid exclusions are left out and reported in the error.
+func NewExclusions(exclusions []Exclusion) (*Exclusions, error) {
+	compiled := &Exclusions{}
+	var errs []error
+	for i, exclusion := range exclusions {
+		if exclusion.Exe == "" && exclusion.Class == "" && exclusion.Title == "" {
+			errs = append(errs, fmt.Errorf("exclusion %d: no exe, class or title", i+1))
+			continue
+		}
+		m, err := compileMatch(exclusion.Match)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("exclusion %d: %w", i+1, err))
+			continue
+		}
+		compiled.exclusions = append(compiled.exclusions, compiledExclusion{Exclusion: exclusion, matcher: m})
+	}
+	return compiled, errors.Join(errs...)
+}
+
+// Len returns the number of valid exclusions
+func (e *Exclusions) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.exclusions)
+}
+
+// Find returns the first exclusion matching the window with the given number of connected monitors
+func (e *Exclusions) Find(w Window, monitorCount int) (Exclusion, bool) {
+	if e == nil {
+		return Exclusion{}, false
+	}
+	for _, exclusion := range e.exclusions {
+		if exclusion.matcher.matches(w, monitorCount) {
+			return exclusion.Exclusion, true
+		}
+	}
+	return Exclusion{}, false
+}
@@ -0,0 +1,41 @@
+package rules
+
+import "testing"
+
+func TestExclusions(t *testing.T) {
+	exclusions, err := NewExclusions([]Exclusion{
+		{Match: Match{Exe: "mstsc.exe"}},
+		{Match: Match{Title: "("}},
+		{Match: Match{Monitors: 2}},
+		{Match: Match{Class: "UnrealWindow"}, AllowMove: true},
+		{Match: Match{Exe: "vlc.exe", Title: `^\S+\.(mkv|mp4) - VLC`}},
+	})
+	if err == nil {
+		t.Error("NewExclusions() should report the invalid exclusions")
+	}
+	if exclusions.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", exclusions.Len())
+	}
+
+	tests := []struct {
+		name      string
+		window    Window
+		excluded  bool
+		allowMove bool
+	}{
+		{"exe ignoring case", Window{Exe: "MSTSC.EXE", Class: "TscShellContainerClass"}, true, false},
+		{"class allowing moves", Window{Exe: "game.exe", Class: "UnrealWindow"}, true, true},
+		{"exe and title", Window{Exe: "vlc.exe", Title: "holiday.mp4 - VLC media player"}, true, false},
+		{"exe without the title", Window{Exe: "vlc.exe", Title: "VLC media player"}, false, false},
+		{"not excluded", Window{Exe: "notepad.exe", Class: "Notepad"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclusion, ok := exclusions.Find(tt.window, 2)
+			if ok != tt.excluded || exclusion.AllowMove != tt.allowMove {
+				t.Errorf("Find() = %+v, %v, want excluded %v with allowMove %v", exclusion, ok, tt.excluded, tt.allowMove)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+// Package rules matches windows against the placement rules and exclusions of
+// the config. It has no Win32 dependencies so the rules can be tested on any OS.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"telewindow/placement"
+)
+
+// Splits the window can be placed in
+const (
+	SplitNone        = ""
+	SplitLeft        = "left"
+	SplitRight       = "right"
+	SplitTop         = "top"
+	SplitBottom      = "bottom"
+	SplitTopLeft     = "topLeft"
+	SplitTopRight    = "topRight"
+	SplitBottomLeft  = "bottomLeft"
+	SplitBottomRight = "bottomRight"
+)
+
+// splitDirections maps the splits to their horizontal and vertical direction, 0 for none
+var splitDirections = map[string][2]int{
+	SplitLeft:        {placement.Left, 0},
+	SplitRight:       {placement.Right, 0},
+	SplitTop:         {0, placement.Up},
+	SplitBottom:      {0, placement.Down},
+	SplitTopLeft:     {placement.Left, placement.Up},
+	SplitTopRight:    {placement.Right, placement.Up},
+	SplitBottomLeft:  {placement.Left, placement.Down},
+	SplitBottomRight: {placement.Right, placement.Down},
+}
+
+// Window is a window the rules are matched against
+type Window struct {
+	Exe   string
+	Class string
+	Title string
+}
+
+// Match selects the windows a rule applies to, empty fields match any window
+type Match struct {
+	// Exe is the executable name, e.g. "firefox.exe", compared ignoring case
+	Exe   string `json:"exe"`
+	Class string `json:"class"`
+	// Title is a regular expression the window title has to match
+	Title string `json:"title"`
+	// Monitors is the number of connected monitors, 0 matches any number
+	Monitors int `json:"monitors"`
+}
+
+// Cell is a block of grid cells of the monitor grid, Columns and Rows default to a single cell
+type Cell struct {
+	Column  int `json:"column"`
+	Row     int `json:"row"`
+	Columns int `json:"columns"`
+	Rows    int `json:"rows"`
+}
+
+// Action places a matched window
+type Action struct {
+	// Monitor is the index (1 is the leftmost monitor), alias or device name of the monitor, empty keeps the current monitor
+	Monitor string `json:"monitor"`
+	// Split snaps the window to a half or quarter of the monitor
+	Split string `json:"split"`
+	// Cell snaps the window to cells of the monitor grid
+	Cell *Cell `json:"cell"`
+	// Maximize maximizes the window on the monitor
+	Maximize bool `json:"maximize"`
+}
+
+// Rule places the windows matching the rule when they are opened
+type Rule struct {
+	Name   string `json:"name"`
+	Match  Match  `json:"match"`
+	Action Action `json:"action"`
+}
+
+// matcher is a match with its title expression compiled
+type matcher struct {
+	Match
+	title *regexp.Regexp
+}
+
+// compiledRule is a rule with its match compiled
+type compiledRule struct {
+	Rule
+	matcher matcher
+}
+
+// Engine evaluates the rules in order, the first matching rule applies
+type Engine struct {
+	rules []compiledRule
+}
+
+// New compiles the rules. Invalid rules are left out and reported in the error, the engine still applies the valid rules.
+func New(rules []Rule) (*Engine, error) {
+	engine := &Engine{}
+	var errs []error
+	for i, rule := range rules {
+		compiled, err := compile(rule)
+		if err != nil {
+			name := rule.Name
+			if name == "" {
+				name = fmt.Sprint(i + 1)
+			}
+			errs = append(errs, fmt.Errorf("rule %s: %w", name, err))
+			continue
+		}
+		engine.rules = append(engine.rules, compiled)
+	}
+	return engine, errors.Join(errs...)
+}
+
+// compileMatch validates the match and compiles its title expression
+func compileMatch(match Match) (matcher, error) {
+	m := matcher{Match: match}
+	if match.Title != "" {
+		title, err := regexp.Compile(match.Title)
+		if err != nil {
+			return m, err
+		}
+		m.title = title
+	}
+	if match.Monitors < 0 {
+		return m, fmt.Errorf("invalid monitor count %d", match.Monitors)
+	}
+	return m, nil
+}
+
+// compile validates the rule and compiles its match
+func compile(rule Rule) (compiledRule, error) {
+	m, err := compileMatch(rule.Match)
+	compiled := compiledRule{Rule: rule, matcher: m}
+	if err != nil {
+		return compiled, err
+	}
+
+	placements := 0
+	if rule.Action.Split != SplitNone {
+		if _, ok := splitDirections[rule.Action.Split]; !ok {
+			return compiled, fmt.Errorf("unknown split %q", rule.Action.Split)
+		}
+		placements++
+	}
+	if cell := rule.Action.Cell; cell != nil {
+		if cell.Column < 0 || cell.Row < 0 || cell.Columns < 0 || cell.Rows < 0 {
+			return compiled, fmt.Errorf("invalid cell %+v", *cell)
+		}
+		placements++
+	}
+	if rule.Action.Maximize {
+		placements++
+	}
+	if placements > 1 {
+		return compiled, errors.New("only one of split, cell and maximize can be set")
+	}
+	if placements == 0 && rule.Action.Monitor == "" {
+		return compiled, errors.New("no action")
+	}
+	return compiled, nil
+}
+
+// Len returns the number of valid rules
+func (e *Engine) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.rules)
+}
+
+// Evaluate returns the first rule matching the window with the given number of connected monitors
+func (e *Engine) Evaluate(w Window, monitorCount int) (Rule, bool) {
+	if e == nil {
+		return Rule{}, false
+	}
+	for _, rule := range e.rules {
+		if rule.matcher.matches(w, monitorCount) {
+			return rule.Rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// matches reports if all the set fields of the match apply to the window
+func (m matcher) matches(w Window, monitorCount int) bool {
+	if m.Exe != "" && !strings.EqualFold(m.Exe, w.Exe) {
+		return false
+	}
+	if m.Class != "" && m.Class != w.Class {
+		return false
+	}
+	if m.title != nil && !m.title.MatchString(w.Title) {
+		return false
+	}
+	if m.Monitors != 0 && m.Monitors != monitorCount {
+		return false
+	}
+	return true
+}
+
+// Rect returns the rect of the split or grid cell within the area of the monitor.
+// It returns false if the action does not snap the window or the cell is outside the grid.
+func (a Action) Rect(area placement.Rect, grid placement.Grid) (placement.Rect, bool) {
+	if directions, ok := splitDirections[a.Split]; ok {
+		switch {
+		case directions[0] != 0 && directions[1] != 0:
+			return placement.Quarter(area, directions[0], directions[1])
+		case directions[0] != 0:
+			return placement.Split(area, directions[0])
+		default:
+			return placement.Split(area, directions[1])
+		}
+	}
+	if a.Cell != nil && grid.Valid() {
+		span := placement.Span{Column: a.Cell.Column, Row: a.Cell.Row, Columns: max(a.Cell.Columns, 1), Rows: max(a.Cell.Rows, 1)}
+		if span.Column+span.Columns > grid.Columns || span.Row+span.Rows > grid.Rows {
+			return placement.Rect{}, false
+		}
+		return grid.Rect(area, span), true
+	}
+	return placement.Rect{}, false
+}
@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"telewindow/placement"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr bool
+	}{
+		{"split", Rule{Match: Match{Exe: "code.exe"}, Action: Action{Split: SplitLeft}}, false},
+		{"monitor only", Rule{Match: Match{Class: "Notepad"}, Action: Action{Monitor: "2"}}, false},
+		{"invalid title", Rule{Match: Match{Title: "("}, Action: Action{Maximize: true}}, true},
+		{"unknown split", Rule{Action: Action{Split: "middle"}}, true},
+		{"negative cell", Rule{Action: Action{Cell: &Cell{Column: -1}}}, true},
+		{"negative monitor count", Rule{Match: Match{Monitors: -1}, Action: Action{Maximize: true}}, true},
+		{"several placements", Rule{Action: Action{Split: SplitRight, Maximize: true}}, true},
+		{"no action", Rule{Match: Match{Exe: "code.exe"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := New([]Rule{tt.rule})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := 1
+			if tt.wantErr {
+				want = 0
+			}
+			if engine.Len() != want {
+				t.Errorf("Len() = %d, want %d", engine.Len(), want)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	engine, err := New([]Rule{
+		{Name: "invalid", Match: Match{Title: "["}, Action: Action{Maximize: true}},
+		{Name: "docked editor", Match: Match{Exe: "Code.exe", Monitors: 2}, Action: Action{Monitor: "2", Maximize: true}},
+		{Name: "editor", Match: Match{Exe: "Code.exe"}, Action: Action{Split: SplitLeft}},
+		{Name: "meetings", Match: Match{Title: `(?i)meeting|call`}, Action: Action{Split: SplitRight}},
+		{Name: "notes", Match: Match{Class: "Notepad"}, Action: Action{Cell: &Cell{Column: 1}}},
+	})
+	if err == nil {
+		t.Error("New() should report the invalid rule")
+	}
+
+	tests := []struct {
+		name     string
+		window   Window
+		monitors int
+		want     string
+	}{
+		{"exe ignoring case", Window{Exe: "code.exe", Title: "main.go"}, 1, "editor"},
+		{"monitor count", Window{Exe: "code.exe", Title: "main.go"}, 2, "docked editor"},
+		{"earlier rule wins", Window{Exe: "code.exe", Title: "Meeting notes"}, 1, "editor"},
+		{"title expression", Window{Exe: "teams.exe", Title: "Weekly Meeting"}, 1, "meetings"},
+		{"class", Window{Exe: "notepad.exe", Class: "Notepad", Title: "todo.txt"}, 3, "notes"},
+		{"class is case sensitive", Window{Exe: "notepad.exe", Class: "notepad"}, 1, ""},
+		{"no match", Window{Exe: "firefox.exe", Title: "News"}, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := engine.Evaluate(tt.window, tt.monitors)
+			if ok != (tt.want != "") || rule.Name != tt.want {
+				t.Errorf("Evaluate() = %q, %v, want %q", rule.Name, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithoutRules(t *testing.T) {
+	var engine *Engine
+	if _, ok := engine.Evaluate(Window{Exe: "code.exe"}, 1); ok {
+		t.Error("a nil engine should not match")
+	}
+}
+
+func TestActionRect(t *testing.T) {
+	area := placement.Rect{Left: 0, Top: 0, Right: 1920, Bottom: 1040}
+	grid := placement.Grid{Columns: 3, Rows: 2}
+	tests := []struct {
+		name   string
+		action Action
+		want   placement.Rect
+		wantOk bool
+	}{
+		{"left half", Action{Split: SplitLeft}, placement.Rect{Left: 0, Top: 0, Right: 960, Bottom: 1040}, true},
+		{"bottom half", Action{Split: SplitBottom}, placement.Rect{Left: 0, Top: 520, Right: 1920, Bottom: 1040}, true},
+		{"top right quarter", Action{Split: SplitTopRight}, placement.Rect{Left: 960, Top: 0, Right: 1920, Bottom: 520}, true},
+		{"single cell", Action{Cell: &Cell{Column: 2, Row: 1}}, placement.Rect{Left: 1280, Top: 520, Right: 1920, Bottom: 1040}, true},
+		{"cell span", Action{Cell: &Cell{Column: 0, Row: 0, Columns: 2, Rows: 2}}, placement.Rect{Left: 0, Top: 0, Right: 1280, Bottom: 1040}, true},
+		{"cell outside the grid", Action{Cell: &Cell{Column: 3}}, placement.Rect{}, false},
+		{"maximize", Action{Maximize: true}, placement.Rect{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.action.Rect(area, grid)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Rect() = %+v, %v, want %+v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
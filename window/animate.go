@@ -0,0 +1,27 @@
+package window
+
+import (
+	"telewindow/animation"
+	"telewindow/placement"
+	"time"
+)
+
+// Animate animates the windows moved and resized by the actions, tiling and restoring layouts are never animated
+var Animate = false
+
+// Animation is the animation of the moved and resized windows
+var Animation = animation.Animation{Duration: 150 * time.Millisecond, FrameRate: 60}
+
+// setWindowRect moves and resizes the window, animated from its current rect if Animate is set
+func setWindowRect(hwnd Handle, rect RECT) error {
+	if !Animate {
+		return backend.SetWindowRect(hwnd, rect)
+	}
+	current, err := backend.WindowRect(hwnd)
+	if err != nil {
+		return backend.SetWindowRect(hwnd, rect)
+	}
+	return Animation.Run(placement.Rect(*current), placement.Rect(rect), func(r placement.Rect) error {
+		return backend.SetWindowRect(hwnd, RECT(r))
+	})
+}
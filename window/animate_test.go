@@ -0,0 +1,55 @@
+package window
+
+import (
+	"reflect"
+	"telewindow/animation"
+	"telewindow/placement"
+	"testing"
+	"time"
+)
+
+// recordingBackend records the rects set on the fake desktop
+type recordingBackend struct {
+	*FakeBackend
+	rects []RECT
+}
+
+func (b *recordingBackend) SetWindowRect(hwnd Handle, rect RECT) error {
+	b.rects = append(b.rects, rect)
+	return b.FakeBackend.SetWindowRect(hwnd, rect)
+}
+
+// stepClock advances only when it sleeps
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time        { return c.now }
+func (c *stepClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestAnimatedMove(t *testing.T) {
+	fake := useFakeBackend(t)
+	recorder := &recordingBackend{FakeBackend: fake}
+	SetBackend(recorder)
+	previousAnimate, previousAnimation := Animate, Animation
+	Animate = true
+	Animation = animation.Animation{Duration: 100 * time.Millisecond, FrameRate: 40, Clock: &stepClock{}}
+	t.Cleanup(func() {
+		Animate, Animation = previousAnimate, previousAnimation
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	MoveActiveWindow(placement.Right)
+	want := []RECT{
+		rect(580, 100, 1540, 640),
+		rect(1060, 100, 2020, 640),
+		rect(1540, 100, 2500, 640),
+		rect(2020, 100, 2980, 640),
+	}
+	if !reflect.DeepEqual(recorder.rects, want) {
+		t.Errorf("frames = %+v, want %+v", recorder.rects, want)
+	}
+	assertRect(t, fake, hwnd, rect(2020, 100, 2980, 640))
+}
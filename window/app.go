@@ -0,0 +1,81 @@
+package window
+
+import "log"
+
+// MoveAppWindows moves all windows of the application of the active window to the monitor in the direction.
+// Every window is moved from its own monitor and keeps its relative size and position, like MoveActiveWindow.
+func MoveAppWindows(direction int) {
+	log.Printf("DEBUG: Entering MoveAppWindows() with direction: %d\n", direction)
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	if excluded(activeWindow, nil, false) {
+		return
+	}
+
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+	if len(monitors) < 2 {
+		log.Println("DEBUG: Only one monitor detected.")
+		return
+	}
+
+	handles := appWindows(activeWindow)
+	log.Printf("DEBUG: Moving %d windows of the application.\n", len(handles))
+	moved := false
+	for _, hwnd := range handles {
+		if hwnd != activeWindow && excluded(hwnd, monitors, false) {
+			continue
+		}
+		rect, _, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			log.Println("DEBUG: Error getting window rect:", err)
+			continue
+		}
+		currentMonitor := findCurrentMonitor(monitors, rect)
+		if currentMonitor == nil {
+			log.Printf("DEBUG: Window %v is not on any monitor, skipping it.\n", hwnd)
+			continue
+		}
+		targetMonitor := findTargetMonitorWithFallback(monitors, currentMonitor, direction)
+		if targetMonitor == nil {
+			log.Printf("DEBUG: No monitor found in the desired direction for window %v.\n", hwnd)
+			continue
+		}
+		if moveWindow(hwnd, monitors, currentMonitor, targetMonitor, placementOptions()) {
+			moved = true
+		}
+	}
+	if moved {
+		log.Println("DEBUG: Application windows moved successfully.")
+		RetileMonitors()
+	}
+}
+
+// appWindows returns the application windows of the process of the window and the windows they own in z-order,
+// the window itself comes last
+func appWindows(hwnd Handle) []Handle {
+	pid, err := backend.ProcessID(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window process:", err)
+		return []Handle{hwnd}
+	}
+	handles, err := backend.ProcessWindows(pid)
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return []Handle{hwnd}
+	}
+
+	var app []Handle
+	for _, h := range handles {
+		if h != hwnd {
+			app = append(app, h)
+		}
+	}
+	return append(app, hwnd)
+}
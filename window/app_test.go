@@ -0,0 +1,37 @@
+package window
+
+import (
+	"reflect"
+	"telewindow/placement"
+	"testing"
+)
+
+func TestMoveAppWindows(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 4480, 1440), rect(1920, 0, 4480, 1440))
+	other := fake.AddWindow(rect(300, 300, 900, 900))
+	fake.SetWindowInfo(other, WindowInfo{Exe: "notepad.exe", ProcessID: 20})
+	editor := fake.AddWindow(rect(960, 0, 1920, 540))
+	fake.SetWindowInfo(editor, WindowInfo{Exe: "idea64.exe", ProcessID: 10})
+	main := fake.AddWindow(rect(0, 0, 960, 1080))
+	fake.SetWindowInfo(main, WindowInfo{Exe: "idea64.exe", ProcessID: 10})
+	tool := fake.AddWindow(rect(480, 540, 960, 1080))
+	fake.SetWindowInfo(tool, WindowInfo{Exe: "idea64.exe", ProcessID: 10})
+	fake.SetWindowOwner(tool, main)
+	fake.SetActiveWindow(main)
+
+	if got, want := appWindows(main), []Handle{tool, editor, main}; !reflect.DeepEqual(got, want) {
+		t.Errorf("appWindows() = %v, want %v", got, want)
+	}
+
+	MoveAppWindows(placement.Right)
+	assertRect(t, fake, main, rect(1920, 0, 3200, 1440))
+	assertRect(t, fake, editor, rect(3200, 0, 4480, 720))
+	assertRect(t, fake, tool, rect(2560, 720, 3200, 1440))
+	assertRect(t, fake, other, rect(300, 300, 900, 900))
+
+	// Windows already on the rightmost monitor stay there
+	MoveAppWindows(placement.Right)
+	assertRect(t, fake, main, rect(1920, 0, 3200, 1440))
+}
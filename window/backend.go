@@ -0,0 +1,58 @@
+package window
+
+// Handle is a window or monitor handle (HWND / HMONITOR)
+type Handle uintptr
+
+// WindowInfo identifies the application a window belongs to
+type WindowInfo struct {
+	// Exe is the file name of the executable, e.g. "notepad.exe"
+	Exe       string
+	Class     string
+	Title     string
+	ProcessID uint32
+}
+
+// Backend is the seam between the window actions and the OS.
+// The Win32 implementation is used on Windows, FakeBackend can be used in tests.
+type Backend interface {
+	// ActiveWindow returns the foreground window
+	ActiveWindow() (Handle, error)
+	// FocusWindow brings the window to the foreground and gives it the keyboard focus
+	FocusWindow(hwnd Handle) error
+	// WindowRect returns the screen coordinates of the window
+	WindowRect(hwnd Handle) (*RECT, error)
+	// Windows enumerates the visible, not minimized top-level application windows in z-order, topmost first
+	Windows() ([]Handle, error)
+	// ProcessWindows enumerates the visible, not minimized application windows of the process and the windows
+	// they own directly or through other owned windows, in z-order, topmost first
+	ProcessWindows(pid uint32) ([]Handle, error)
+	// ProcessID returns the ID of the process that created the window
+	ProcessID(hwnd Handle) (uint32, error)
+	// WindowInfo returns the executable, class and title of the window
+	WindowInfo(hwnd Handle) (WindowInfo, error)
+	// IsWindow reports if the window still exists
+	IsWindow(hwnd Handle) bool
+	// VisibleRect returns the screen coordinates of the visible window frame, without invisible resize borders
+	VisibleRect(hwnd Handle) (*RECT, error)
+	// ShowState returns the show command of the window (SW_SHOWNORMAL, SW_SHOWMAXIMIZED, SW_SHOWMINIMIZED)
+	ShowState(hwnd Handle) (uint32, error)
+	// ShowWindow changes the show state of the window (SW_MAXIMIZE, SW_RESTORE)
+	ShowWindow(hwnd Handle, cmd int) error
+	// SetWindowRect moves and resizes the window
+	SetWindowRect(hwnd Handle, rect RECT) error
+	// Monitors enumerates all display monitors
+	Monitors() ([]Monitor, error)
+}
+
+// backend is set to the Win32 backend on Windows, other platforms have to call SetBackend
+var backend Backend
+
+// SetBackend replaces the backend used by all window actions
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// CurrentBackend returns the backend used by all window actions
+func CurrentBackend() Backend {
+	return backend
+}
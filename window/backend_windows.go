@@ -0,0 +1,406 @@
+package window
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Globals
+var (
+	user32                    = windows.NewLazySystemDLL("user32.dll")
+	procMoveWindow            = user32.NewProc("MoveWindow")
+	procShowWindow            = user32.NewProc("ShowWindow")
+	procGetWindowPlacement    = user32.NewProc("GetWindowPlacement")
+	procGetForegroundWindow   = user32.NewProc("GetForegroundWindow")
+	procSetForegroundWindow   = user32.NewProc("SetForegroundWindow")
+	procAttachThreadInput     = user32.NewProc("AttachThreadInput")
+	procGetWindowRect         = user32.NewProc("GetWindowRect")
+	procIsWindow              = user32.NewProc("IsWindow")
+	procEnumWindows           = user32.NewProc("EnumWindows")
+	procIsWindowVisible       = user32.NewProc("IsWindowVisible")
+	procIsIconic              = user32.NewProc("IsIconic")
+	procGetWindow             = user32.NewProc("GetWindow")
+	procGetWindowLong         = user32.NewProc("GetWindowLongW")
+	procGetShellWindow        = user32.NewProc("GetShellWindow")
+	procGetClassName          = user32.NewProc("GetClassNameW")
+	procGetWindowText         = user32.NewProc("GetWindowTextW")
+	procGetWindowThreadPID    = user32.NewProc("GetWindowThreadProcessId")
+	procEnumDisplayMonitors   = user32.NewProc("EnumDisplayMonitors")
+	procGetMonitorInfo        = user32.NewProc("GetMonitorInfoW")
+	dwmapi                    = windows.NewLazySystemDLL("dwmapi.dll")
+	procDwmGetWindowAttribute = dwmapi.NewProc("DwmGetWindowAttribute")
+	shcore                    = windows.NewLazySystemDLL("shcore.dll")
+	procGetDpiForMonitor      = shcore.NewProc("GetDpiForMonitor")
+	// procSetWindowPos       = user32.NewProc("SetWindowPos")
+	// procSetWindowPlacement = user32.NewProc("SetWindowPlacement")
+	// procSendMessage        = user32.NewProc("SendMessageW")
+	// procInvalidateRect     = user32.NewProc("InvalidateRect")
+	// procUpdateWindow       = user32.NewProc("UpdateWindow")
+)
+
+type WINDOWPLACEMENT struct {
+	Length           uint32
+	Flags            uint32
+	ShowCmd          uint32
+	PtMinPosition    Point
+	PtMaxPosition    Point
+	RcNormalPosition RECT
+}
+
+// DWMWA_EXTENDED_FRAME_BOUNDS is the DWM window attribute for the visible window frame
+const DWMWA_EXTENDED_FRAME_BOUNDS = 9
+
+// DWMWA_CLOAKED is the DWM window attribute for windows hidden by the shell, e.g. on other virtual desktops
+const DWMWA_CLOAKED = 14
+
+const (
+	// GetWindow command for the owner window
+	GW_OWNER = 4
+
+	// GetWindowLong index and flag of the extended window styles
+	GWL_EXSTYLE      = -20
+	WS_EX_TOOLWINDOW = 0x00000080
+)
+
+// MDT_EFFECTIVE_DPI is the MONITOR_DPI_TYPE used for scaling
+const MDT_EFFECTIVE_DPI = 0
+
+// MONITORINFOEX extends MONITORINFO with the device name
+type MONITORINFOEX struct {
+	MONITORINFO
+	SzDevice [32]uint16
+}
+
+func init() {
+	SetBackend(win32Backend{})
+}
+
+// win32Backend talks to user32.dll
+type win32Backend struct{}
+
+func (win32Backend) ActiveWindow() (Handle, error) {
+	ret, _, err := procGetForegroundWindow.Call()
+	if ret == 0 {
+		return 0, fmt.Errorf("GetForegroundWindow failed: %v", err)
+	}
+	return Handle(ret), nil
+}
+
+func (win32Backend) FocusWindow(hwnd Handle) error {
+	// Only the thread of the foreground window may change the foreground window,
+	// share its input state while switching. The attachment belongs to the OS thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	foreground, _, _ := procGetForegroundWindow.Call()
+	foregroundThread, _, _ := procGetWindowThreadPID.Call(foreground, 0)
+	currentThread := uintptr(windows.GetCurrentThreadId())
+	if foregroundThread != 0 && foregroundThread != currentThread {
+		procAttachThreadInput.Call(currentThread, foregroundThread, 1)
+		defer procAttachThreadInput.Call(currentThread, foregroundThread, 0)
+	}
+
+	ret, _, err := procSetForegroundWindow.Call(uintptr(hwnd))
+	if ret == 0 {
+		return fmt.Errorf("SetForegroundWindow failed: %v", err)
+	}
+	return nil
+}
+
+func (win32Backend) WindowRect(hwnd Handle) (*RECT, error) {
+	var rect RECT
+	ret, _, err := procGetWindowRect.Call(
+		uintptr(hwnd),
+		uintptr(unsafe.Pointer(&rect)),
+	)
+	if ret == 0 {
+		return nil, fmt.Errorf("GetWindowRect failed: %v", err)
+	}
+	return &rect, nil
+}
+
+func (win32Backend) Windows() ([]Handle, error) {
+	all, err := topLevelWindows()
+	if err != nil {
+		return nil, err
+	}
+	var handles []Handle
+	for _, hwnd := range all {
+		if isApplicationWindow(hwnd) {
+			handles = append(handles, hwnd)
+		}
+	}
+	return handles, nil
+}
+
+// The enumeration callbacks are created once, the runtime only has room for a limited number of callbacks.
+// They collect the handles in the package slices while the enumeration holds enumMu.
+var (
+	enumMu          sync.Mutex
+	enumHandles     []Handle
+	enumWindowsProc = syscall.NewCallback(func(hwnd windows.Handle, lParam uintptr) uintptr {
+		enumHandles = append(enumHandles, Handle(hwnd))
+		return 1 // Continue enumeration
+	})
+	enumMonitorsProc = syscall.NewCallback(func(hMonitor windows.Handle, hdcMonitor windows.Handle, lprcMonitor *RECT, lParam uintptr) uintptr {
+		enumHandles = append(enumHandles, Handle(hMonitor))
+		return 1 // Continue enumeration
+	})
+)
+
+// topLevelWindows enumerates all top-level windows in z-order, topmost first
+func topLevelWindows() ([]Handle, error) {
+	enumMu.Lock()
+	defer enumMu.Unlock()
+
+	enumHandles = nil
+	ret, _, err := procEnumWindows.Call(enumWindowsProc, 0)
+	handles := enumHandles
+	enumHandles = nil
+	if ret == 0 {
+		return nil, fmt.Errorf("EnumWindows failed: %v", err)
+	}
+	return handles, nil
+}
+
+// monitorHandles enumerates the handles of all display monitors
+func monitorHandles() ([]Handle, error) {
+	enumMu.Lock()
+	defer enumMu.Unlock()
+
+	enumHandles = nil
+	ret, _, err := procEnumDisplayMonitors.Call(0, 0, enumMonitorsProc, 0)
+	handles := enumHandles
+	enumHandles = nil
+	if ret == 0 {
+		return nil, fmt.Errorf("EnumDisplayMonitors failed: %v", err)
+	}
+	return handles, nil
+}
+
+func (b win32Backend) ProcessWindows(pid uint32) ([]Handle, error) {
+	all, err := topLevelWindows()
+	if err != nil {
+		return nil, err
+	}
+	var handles []Handle
+	for _, hwnd := range all {
+		if ret, _, _ := procIsWindowVisible.Call(uintptr(hwnd)); ret == 0 {
+			continue
+		}
+		if ret, _, _ := procIsIconic.Call(uintptr(hwnd)); ret != 0 {
+			continue
+		}
+		// Follow the owner chain up to the application window
+		root := hwnd
+		for o, _, _ := procGetWindow.Call(uintptr(root), GW_OWNER); o != 0; o, _, _ = procGetWindow.Call(o, GW_OWNER) {
+			root = Handle(o)
+		}
+		if owner, err := b.ProcessID(root); err != nil || owner != pid || !isApplicationWindow(root) {
+			continue
+		}
+		handles = append(handles, hwnd)
+	}
+	return handles, nil
+}
+
+func (win32Backend) ProcessID(hwnd Handle) (uint32, error) {
+	var pid uint32
+	ret, _, err := procGetWindowThreadPID.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&pid)))
+	if ret == 0 {
+		return 0, fmt.Errorf("GetWindowThreadProcessId failed: %v", err)
+	}
+	return pid, nil
+}
+
+// isApplicationWindow reports if the window is a visible, not minimized top-level window as shown in the task bar
+func isApplicationWindow(hwnd Handle) bool {
+	if ret, _, _ := procIsWindowVisible.Call(uintptr(hwnd)); ret == 0 {
+		return false
+	}
+	if ret, _, _ := procIsIconic.Call(uintptr(hwnd)); ret != 0 {
+		return false
+	}
+	if shell, _, _ := procGetShellWindow.Call(); shell == uintptr(hwnd) {
+		return false
+	}
+	if owner, _, _ := procGetWindow.Call(uintptr(hwnd), GW_OWNER); owner != 0 {
+		return false
+	}
+	gwlExStyle := GWL_EXSTYLE
+	if exStyle, _, _ := procGetWindowLong.Call(uintptr(hwnd), uintptr(gwlExStyle)); exStyle&WS_EX_TOOLWINDOW != 0 {
+		return false
+	}
+	if procDwmGetWindowAttribute.Find() == nil {
+		var cloaked uint32
+		ret, _, _ := procDwmGetWindowAttribute.Call(
+			uintptr(hwnd),
+			DWMWA_CLOAKED,
+			uintptr(unsafe.Pointer(&cloaked)),
+			unsafe.Sizeof(cloaked),
+		)
+		if ret == 0 && cloaked != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (win32Backend) WindowInfo(hwnd Handle) (WindowInfo, error) {
+	var info WindowInfo
+
+	pid, err := win32Backend{}.ProcessID(hwnd)
+	if err != nil {
+		return info, err
+	}
+	info.ProcessID = pid
+
+	buf := make([]uint16, 256)
+	ret, _, _ := procGetClassName.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	info.Class = windows.UTF16ToString(buf[:ret])
+
+	buf = make([]uint16, 512)
+	ret, _, _ = procGetWindowText.Call(uintptr(hwnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	info.Title = windows.UTF16ToString(buf[:ret])
+
+	info.Exe = processExe(pid)
+	return info, nil
+}
+
+// processExe returns the file name of the executable of the process, empty if it cannot be queried (e.g. elevated processes)
+func processExe(pid uint32) string {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		log.Printf("DEBUG: OpenProcess failed for process %d: %v\n", pid, err)
+		return ""
+	}
+	defer windows.CloseHandle(process)
+
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+	if err := windows.QueryFullProcessImageName(process, 0, &buf[0], &size); err != nil {
+		log.Printf("DEBUG: QueryFullProcessImageName failed for process %d: %v\n", pid, err)
+		return ""
+	}
+	return filepath.Base(windows.UTF16ToString(buf[:size]))
+}
+
+func (win32Backend) IsWindow(hwnd Handle) bool {
+	ret, _, _ := procIsWindow.Call(uintptr(hwnd))
+	return ret != 0
+}
+
+func (win32Backend) VisibleRect(hwnd Handle) (*RECT, error) {
+	if err := procDwmGetWindowAttribute.Find(); err != nil {
+		return nil, err
+	}
+	var rect RECT
+	ret, _, _ := procDwmGetWindowAttribute.Call(
+		uintptr(hwnd),
+		DWMWA_EXTENDED_FRAME_BOUNDS,
+		uintptr(unsafe.Pointer(&rect)),
+		unsafe.Sizeof(rect),
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("DwmGetWindowAttribute failed: %#x", ret)
+	}
+	return &rect, nil
+}
+
+func (win32Backend) ShowState(hwnd Handle) (uint32, error) {
+	var wp WINDOWPLACEMENT
+	wp.Length = uint32(unsafe.Sizeof(wp))
+
+	ret, _, err := procGetWindowPlacement.Call(
+		uintptr(hwnd),
+		uintptr(unsafe.Pointer(&wp)),
+	)
+	if ret == 0 {
+		return 0, fmt.Errorf("GetWindowPlacement failed: %v", err)
+	}
+	return wp.ShowCmd, nil
+}
+
+func (win32Backend) ShowWindow(hwnd Handle, cmd int) error {
+	ret, _, err := procShowWindow.Call(
+		uintptr(hwnd),
+		uintptr(cmd),
+	)
+	if ret == 0 {
+		return fmt.Errorf("ShowWindow failed: %v", err)
+	}
+	return nil
+}
+
+func (win32Backend) SetWindowRect(hwnd Handle, rect RECT) error {
+	ret, _, err := procMoveWindow.Call(
+		uintptr(hwnd),
+		uintptr(rect.Left),
+		uintptr(rect.Top),
+		uintptr(rect.Right-rect.Left),
+		uintptr(rect.Bottom-rect.Top),
+		1, // Repaint
+	)
+	if ret == 0 {
+		return fmt.Errorf("MoveWindow failed: %v", err)
+	}
+	return nil
+}
+
+func (win32Backend) Monitors() ([]Monitor, error) {
+	handles, err := monitorHandles()
+	if err != nil {
+		return nil, err
+	}
+
+	var monitors []Monitor
+	for _, hMonitor := range handles {
+		log.Printf("DEBUG: Enumerating monitor: %v\n", hMonitor)
+		var mi MONITORINFOEX
+		mi.CbSize = uint32(unsafe.Sizeof(mi))
+		ret, _, _ := procGetMonitorInfo.Call(
+			uintptr(hMonitor),
+			uintptr(unsafe.Pointer(&mi)),
+		)
+		if ret == 0 {
+			log.Println("DEBUG: GetMonitorInfo failed, continuing enumeration")
+			continue
+		}
+		monitor := Monitor{
+			HMonitor: hMonitor,
+			Name:     windows.UTF16ToString(mi.SzDevice[:]),
+			Info:     mi.MONITORINFO,
+			Center:   calculateMonitorCenter(mi.MONITORINFO),
+			DPI:      monitorDPI(windows.Handle(hMonitor)),
+		}
+		monitors = append(monitors, monitor)
+		log.Printf("DEBUG: Added monitor %s (%d DPI): %+v\n", monitor.Name, monitor.DPI, mi.MONITORINFO)
+	}
+	return monitors, nil
+}
+
+// monitorDPI returns the effective DPI of the monitor, 0 if it is not available (before Windows 8.1)
+func monitorDPI(hMonitor windows.Handle) uint32 {
+	if procGetDpiForMonitor.Find() != nil {
+		return 0
+	}
+	var dpiX, dpiY uint32
+	ret, _, _ := procGetDpiForMonitor.Call(
+		uintptr(hMonitor),
+		MDT_EFFECTIVE_DPI,
+		uintptr(unsafe.Pointer(&dpiX)),
+		uintptr(unsafe.Pointer(&dpiY)),
+	)
+	if ret != 0 {
+		log.Printf("DEBUG: GetDpiForMonitor failed: %#x\n", ret)
+		return 0
+	}
+	return dpiX
+}
@@ -2,7 +2,12 @@ package window
 
 import (
 	"encoding/json"
+	"log"
 	"os"
+	"telewindow/animation"
+	"telewindow/placement"
+	"telewindow/rules"
+	"time"
 )
 
 type KeyBinding struct {
@@ -43,20 +48,192 @@ func (kb KeyBinding) Down(keyDownMap map[string]bool) bool {
 	return true
 }
 
+// MonitorKeyBinding binds a key combination to a monitor index (1 is the leftmost monitor) or alias
+type MonitorKeyBinding struct {
+	KeyBinding
+	Monitor string `json:"monitor"`
+}
+
 type Config struct {
-	AllowNonAdmin bool `json:"allowNonAdmin"`
-	SizeByPixel   bool `json:"sizeByPixel"`
-	KeyBindings   struct {
-		MoveRight      KeyBinding `json:"moveRight"`
-		MoveLeft       KeyBinding `json:"moveLeft"`
-		MoveUp         KeyBinding `json:"moveUp"`
-		MoveDown       KeyBinding `json:"moveDown"`
-		ToggleMaximize KeyBinding `json:"toggleMaximize"`
-		SplitLeft      KeyBinding `json:"splitLeft"`
-		SplitRight     KeyBinding `json:"splitRight"`
-		SplitUp        KeyBinding `json:"splitUp"`
-		SplitDown      KeyBinding `json:"splitDown"`
+	AllowNonAdmin     bool           `json:"allowNonAdmin"`
+	SizeByPixel       bool           `json:"sizeByPixel"`
+	ScaleByDPI        bool           `json:"scaleByDpi"`
+	UseWorkArea       bool           `json:"useWorkArea"`
+	MonitorFallback   string         `json:"monitorFallback"`
+	NeighborAlgorithm string         `json:"neighborAlgorithm"`
+	ClampToMonitor    string         `json:"clampToMonitor"`
+	HistoryLimit      int            `json:"historyLimit"`
+	NudgeStep         placement.Step `json:"nudgeStep"`
+	RestoreLayouts    bool           `json:"restoreLayoutsOnTopologyChange"`
+	KeyBindings       struct {
+		MoveRight            KeyBinding          `json:"moveRight"`
+		MoveLeft             KeyBinding          `json:"moveLeft"`
+		MoveUp               KeyBinding          `json:"moveUp"`
+		MoveDown             KeyBinding          `json:"moveDown"`
+		ToggleMaximize       KeyBinding          `json:"toggleMaximize"`
+		SplitLeft            KeyBinding          `json:"splitLeft"`
+		SplitRight           KeyBinding          `json:"splitRight"`
+		SplitUp              KeyBinding          `json:"splitUp"`
+		SplitDown            KeyBinding          `json:"splitDown"`
+		SplitTopLeft         KeyBinding          `json:"splitTopLeft"`
+		SplitTopRight        KeyBinding          `json:"splitTopRight"`
+		SplitBottomLeft      KeyBinding          `json:"splitBottomLeft"`
+		SplitBottomRight     KeyBinding          `json:"splitBottomRight"`
+		GridSnap             KeyBinding          `json:"gridSnap"`
+		GridMoveLeft         KeyBinding          `json:"gridMoveLeft"`
+		GridMoveRight        KeyBinding          `json:"gridMoveRight"`
+		GridMoveUp           KeyBinding          `json:"gridMoveUp"`
+		GridMoveDown         KeyBinding          `json:"gridMoveDown"`
+		GridGrowLeft         KeyBinding          `json:"gridGrowLeft"`
+		GridGrowRight        KeyBinding          `json:"gridGrowRight"`
+		GridGrowUp           KeyBinding          `json:"gridGrowUp"`
+		GridGrowDown         KeyBinding          `json:"gridGrowDown"`
+		GridShrinkLeft       KeyBinding          `json:"gridShrinkLeft"`
+		GridShrinkRight      KeyBinding          `json:"gridShrinkRight"`
+		GridShrinkUp         KeyBinding          `json:"gridShrinkUp"`
+		GridShrinkDown       KeyBinding          `json:"gridShrinkDown"`
+		MoveToMonitor        []MonitorKeyBinding `json:"moveToMonitor"`
+		SwapWithMonitorLeft  KeyBinding          `json:"swapWithMonitorLeft"`
+		SwapWithMonitorRight KeyBinding          `json:"swapWithMonitorRight"`
+		SwapWithMonitorUp    KeyBinding          `json:"swapWithMonitorUp"`
+		SwapWithMonitorDown  KeyBinding          `json:"swapWithMonitorDown"`
+		MoveAppWindowsLeft   KeyBinding          `json:"moveAppWindowsLeft"`
+		MoveAppWindowsRight  KeyBinding          `json:"moveAppWindowsRight"`
+		MoveAppWindowsUp     KeyBinding          `json:"moveAppWindowsUp"`
+		MoveAppWindowsDown   KeyBinding          `json:"moveAppWindowsDown"`
+		FocusLeft            KeyBinding          `json:"focusLeft"`
+		FocusRight           KeyBinding          `json:"focusRight"`
+		FocusUp              KeyBinding          `json:"focusUp"`
+		FocusDown            KeyBinding          `json:"focusDown"`
+		Gather               KeyBinding          `json:"gather"`
+		Scatter              KeyBinding          `json:"scatter"`
+		Unsnap               KeyBinding          `json:"unsnap"`
+		Center               KeyBinding          `json:"center"`
+		NudgeLeft            KeyBinding          `json:"nudgeLeft"`
+		NudgeRight           KeyBinding          `json:"nudgeRight"`
+		NudgeUp              KeyBinding          `json:"nudgeUp"`
+		NudgeDown            KeyBinding          `json:"nudgeDown"`
+		GrowLeft             KeyBinding          `json:"growLeft"`
+		GrowRight            KeyBinding          `json:"growRight"`
+		GrowUp               KeyBinding          `json:"growUp"`
+		GrowDown             KeyBinding          `json:"growDown"`
+		ShrinkLeft           KeyBinding          `json:"shrinkLeft"`
+		ShrinkRight          KeyBinding          `json:"shrinkRight"`
+		ShrinkUp             KeyBinding          `json:"shrinkUp"`
+		ShrinkDown           KeyBinding          `json:"shrinkDown"`
+		Undo                 KeyBinding          `json:"undo"`
+		Redo                 KeyBinding          `json:"redo"`
 	} `json:"keyBindings"`
+	SplitCycle struct {
+		Left  []float64 `json:"left"`
+		Right []float64 `json:"right"`
+		Up    []float64 `json:"up"`
+		Down  []float64 `json:"down"`
+	} `json:"splitCycle"`
+	Grid struct {
+		Default  placement.Grid            `json:"default"`
+		Monitors map[string]placement.Grid `json:"monitors"`
+	} `json:"grid"`
+	MonitorAliases map[string]string `json:"monitorAliases"`
+	Tiling         struct {
+		Enabled  bool                        `json:"enabled"`
+		Default  placement.Layout            `json:"default"`
+		Monitors map[string]placement.Layout `json:"monitors"`
+	} `json:"tiling"`
+	Animation struct {
+		Enabled   bool   `json:"enabled"`
+		Duration  int    `json:"duration"`
+		Easing    string `json:"easing"`
+		FrameRate int    `json:"frameRate"`
+	} `json:"animation"`
+	Rules      []rules.Rule      `json:"rules"`
+	Exclusions []rules.Exclusion `json:"exclusions"`
+}
+
+// ApplyConfig sets the global window settings from the config
+func ApplyConfig(config *Config) {
+	SizeByPixel = config.SizeByPixel
+	ScaleByDPI = config.ScaleByDPI
+	UseWorkArea = config.UseWorkArea
+	switch config.MonitorFallback {
+	case FallbackNone, FallbackWrap, FallbackNearest:
+		MonitorFallback = config.MonitorFallback
+	default:
+		log.Printf("WARNING: Unknown monitorFallback %q, using %q\n", config.MonitorFallback, FallbackNone)
+		MonitorFallback = FallbackNone
+	}
+	switch config.NeighborAlgorithm {
+	case NeighborEdge, NeighborCenter:
+		NeighborAlgorithm = config.NeighborAlgorithm
+	default:
+		log.Printf("WARNING: Unknown neighborAlgorithm %q, using %q\n", config.NeighborAlgorithm, NeighborEdge)
+		NeighborAlgorithm = NeighborEdge
+	}
+	switch config.ClampToMonitor {
+	case ClampShrink, ClampShift, ClampNone:
+		ClampToMonitor = config.ClampToMonitor
+	default:
+		log.Printf("WARNING: Unknown clampToMonitor %q, using %q\n", config.ClampToMonitor, ClampShrink)
+		ClampToMonitor = ClampShrink
+	}
+	if config.HistoryLimit > 0 {
+		HistoryLimit = config.HistoryLimit
+	} else {
+		log.Printf("WARNING: Invalid historyLimit %d, using 20\n", config.HistoryLimit)
+		HistoryLimit = 20
+	}
+	NudgeStep = config.NudgeStep
+	AutoRestoreLayouts = config.RestoreLayouts
+	SplitCycle = config.SplitCycles()
+	DefaultGrid = config.Grid.Default
+	Grids = config.Grid.Monitors
+	MonitorAliases = config.MonitorAliases
+	Tiling = config.Tiling.Enabled
+	DefaultLayout = validLayout(config.Tiling.Default)
+	Layouts = map[string]placement.Layout{}
+	for monitor, layout := range config.Tiling.Monitors {
+		Layouts[monitor] = validLayout(layout)
+	}
+	Animate = config.Animation.Enabled
+	easing, ok := animation.EasingByName(config.Animation.Easing)
+	if !ok {
+		log.Printf("WARNING: Unknown animation easing %q, using %q\n", config.Animation.Easing, animation.Linear)
+		easing, _ = animation.EasingByName(animation.Linear)
+	}
+	Animation = animation.Animation{
+		Duration:  time.Duration(config.Animation.Duration) * time.Millisecond,
+		FrameRate: config.Animation.FrameRate,
+		Easing:    easing,
+	}
+	engine, err := rules.New(config.Rules)
+	if err != nil {
+		log.Printf("WARNING: Invalid rules are ignored: %v\n", err)
+	}
+	Rules = engine
+	exclusions, err := rules.NewExclusions(config.Exclusions)
+	if err != nil {
+		log.Printf("WARNING: Invalid exclusions are ignored: %v\n", err)
+	}
+	Exclusions = exclusions
+}
+
+// validLayout returns the layout or no layout if the kind is unknown
+func validLayout(layout placement.Layout) placement.Layout {
+	if !layout.Valid() {
+		log.Printf("WARNING: Unknown layout %q, windows are not tiled\n", layout.Kind)
+		return placement.Layout{}
+	}
+	return layout
+}
+
+// SplitCycles returns the split fractions per direction
+func (c *Config) SplitCycles() map[int][]float64 {
+	return map[int][]float64{
+		placement.Left:  c.SplitCycle.Left,
+		placement.Right: c.SplitCycle.Right,
+		placement.Up:    c.SplitCycle.Up,
+		placement.Down:  c.SplitCycle.Down,
+	}
 }
 
 func LoadConfig() (*Config, error) {
@@ -65,7 +242,19 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
-	var config Config
+	// Defaults for settings missing in the config file
+	config := Config{
+		UseWorkArea:       true,
+		MonitorFallback:   FallbackNone,
+		NeighborAlgorithm: NeighborEdge,
+		ClampToMonitor:    ClampShrink,
+		HistoryLimit:      20,
+	}
+	config.Grid.Default = placement.Grid{Columns: 2, Rows: 2}
+	config.NudgeStep = placement.Step{Pixels: 50}
+	config.Animation.Duration = 150
+	config.Animation.Easing = animation.EaseOut
+	config.Animation.FrameRate = 60
 	err = json.Unmarshal(data, &config)
 	if err != nil {
 		return nil, err
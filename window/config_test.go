@@ -0,0 +1,32 @@
+package window
+
+import (
+	"os"
+	"testing"
+)
+
+func TestShippedConfig(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(".."); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Chdir(dir)
+	})
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sample rules would start the window watcher on every install
+	if len(config.Rules) != 0 {
+		t.Errorf("rules = %+v, want none", config.Rules)
+	}
+	// Sample exclusions would look up the process of every window an action touches
+	if len(config.Exclusions) != 0 {
+		t.Errorf("exclusions = %+v, want none", config.Exclusions)
+	}
+}
@@ -0,0 +1,176 @@
+package window
+
+import (
+	"log"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procSetWinEventHook  = user32.NewProc("SetWinEventHook")
+	procGetAncestor      = user32.NewProc("GetAncestor")
+	procGetMessage       = user32.NewProc("GetMessageW")
+	procTranslateMessage = user32.NewProc("TranslateMessage")
+	procDispatchMessage  = user32.NewProc("DispatchMessageW")
+	procRegisterClassEx  = user32.NewProc("RegisterClassExW")
+	procCreateWindowEx   = user32.NewProc("CreateWindowExW")
+	procDefWindowProc    = user32.NewProc("DefWindowProcW")
+)
+
+const (
+	// Window events of SetWinEventHook
+	EVENT_SYSTEM_MINIMIZESTART = 0x0016
+	EVENT_SYSTEM_MINIMIZEEND   = 0x0017
+	EVENT_OBJECT_DESTROY       = 0x8001
+	EVENT_OBJECT_SHOW          = 0x8002
+	EVENT_OBJECT_HIDE          = 0x8003
+	EVENT_OBJECT_CLOAKED       = 0x8017
+	EVENT_OBJECT_UNCLOAKED     = 0x8018
+
+	WINEVENT_OUTOFCONTEXT   = 0x0000
+	WINEVENT_SKIPOWNPROCESS = 0x0002
+
+	// Object and child ID of events about the window itself
+	OBJID_WINDOW = 0
+	CHILDID_SELF = 0
+
+	// GetAncestor flag for the root window
+	GA_ROOT = 2
+
+	// WM_DISPLAYCHANGE is broadcast to the top-level windows when the display resolution or monitors changed
+	WM_DISPLAYCHANGE = 0x007E
+)
+
+// WNDCLASSEX describes a window class for RegisterClassEx
+type WNDCLASSEX struct {
+	CbSize        uint32
+	Style         uint32
+	LpfnWndProc   uintptr
+	CbClsExtra    int32
+	CbWndExtra    int32
+	HInstance     uintptr
+	HIcon         uintptr
+	HCursor       uintptr
+	HbrBackground uintptr
+	LpszMenuName  *uint16
+	LpszClassName *uint16
+	HIconSm       uintptr
+}
+
+// MSG is a message of the thread message queue
+type MSG struct {
+	Hwnd     uintptr
+	Message  uint32
+	WParam   uintptr
+	LParam   uintptr
+	Time     uint32
+	Pt       Point
+	LPrivate uint32
+}
+
+var (
+	windowEvents     = make(chan struct{}, 1)
+	windowEventsOnce sync.Once
+	// winEventProc is registered once for all hooks, the runtime only has room for a limited number of callbacks
+	winEventProc = syscall.NewCallback(func(hook, event, hwnd, idObject, idChild, thread, time uintptr) uintptr {
+		if int32(idObject) != OBJID_WINDOW || int32(idChild) != CHILDID_SELF {
+			return 0
+		}
+		if root, _, _ := procGetAncestor.Call(hwnd, GA_ROOT); root != hwnd {
+			return 0
+		}
+		notify(windowEvents)
+		return 0
+	})
+)
+
+// WindowEvents returns a channel that receives a value when top-level windows were shown, hidden, closed,
+// minimized or restored. Events that arrive while a value is pending are merged into it.
+func WindowEvents() <-chan struct{} {
+	windowEventsOnce.Do(func() {
+		go func() {
+			// Out of context hooks are called on the thread that set them while it pumps messages
+			runtime.LockOSThread()
+			ranges := [][2]uintptr{
+				{EVENT_SYSTEM_MINIMIZESTART, EVENT_SYSTEM_MINIMIZEEND},
+				{EVENT_OBJECT_DESTROY, EVENT_OBJECT_HIDE},
+				{EVENT_OBJECT_CLOAKED, EVENT_OBJECT_UNCLOAKED},
+			}
+			for _, r := range ranges {
+				hook, _, err := procSetWinEventHook.Call(r[0], r[1], 0, winEventProc, 0, 0, WINEVENT_OUTOFCONTEXT|WINEVENT_SKIPOWNPROCESS)
+				if hook == 0 {
+					log.Println("DEBUG: SetWinEventHook failed:", err)
+				}
+			}
+			pumpMessages()
+		}()
+	})
+	return windowEvents
+}
+
+var (
+	displayChanges     = make(chan struct{}, 1)
+	displayChangesOnce sync.Once
+	displayWndProc     = syscall.NewCallback(func(hwnd, msg, wParam, lParam uintptr) uintptr {
+		if msg == WM_DISPLAYCHANGE {
+			notify(displayChanges)
+		}
+		ret, _, _ := procDefWindowProc.Call(hwnd, msg, wParam, lParam)
+		return ret
+	})
+)
+
+// DisplayChanges returns a channel that receives a value when monitors were connected, disconnected or changed resolution.
+// Changes that arrive while a value is pending are merged into it.
+func DisplayChanges() <-chan struct{} {
+	displayChangesOnce.Do(func() {
+		go func() {
+			// WM_DISPLAYCHANGE is only sent to top-level windows, a hidden window receives it on this thread
+			runtime.LockOSThread()
+			var instance windows.Handle
+			if err := windows.GetModuleHandleEx(0, nil, &instance); err != nil {
+				log.Println("DEBUG: GetModuleHandleEx failed:", err)
+			}
+			className, _ := syscall.UTF16PtrFromString("TeleWindowDisplayChange")
+			class := WNDCLASSEX{LpfnWndProc: displayWndProc, HInstance: uintptr(instance), LpszClassName: className}
+			class.CbSize = uint32(unsafe.Sizeof(class))
+			if atom, _, err := procRegisterClassEx.Call(uintptr(unsafe.Pointer(&class))); atom == 0 {
+				log.Println("DEBUG: RegisterClassEx failed:", err)
+				return
+			}
+			hwnd, _, err := procCreateWindowEx.Call(0, uintptr(unsafe.Pointer(className)), 0, 0, 0, 0, 0, 0, 0, 0, uintptr(instance), 0)
+			if hwnd == 0 {
+				log.Println("DEBUG: CreateWindowEx failed:", err)
+				return
+			}
+			pumpMessages()
+		}()
+	})
+	return displayChanges
+}
+
+// notify sends a value to the channel unless one is already pending
+func notify(events chan struct{}) {
+	select {
+	case events <- struct{}{}:
+	default:
+	}
+}
+
+// pumpMessages dispatches the messages of the current thread until the message queue is closed
+func pumpMessages() {
+	var msg MSG
+	for {
+		ret, _, err := procGetMessage.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+		if int32(ret) <= 0 {
+			log.Println("DEBUG: GetMessage stopped:", err)
+			return
+		}
+		procTranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
+		procDispatchMessage.Call(uintptr(unsafe.Pointer(&msg)))
+	}
+}
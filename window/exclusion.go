@@ -0,0 +1,50 @@
+package window
+
+import (
+	"log"
+	"telewindow/rules"
+)
+
+// Exclusions are the windows the actions leave alone, nil excludes no windows
+var Exclusions *rules.Exclusions
+
+// exclusionFor returns the exclusion matching the window.
+// monitors are the connected monitors, actions looping over windows pass them so they are enumerated once, nil looks them up.
+func exclusionFor(hwnd Handle, monitors []Monitor) (rules.Exclusion, bool) {
+	if Exclusions.Len() == 0 {
+		return rules.Exclusion{}, false
+	}
+	info, err := backend.WindowInfo(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window info:", err)
+		return rules.Exclusion{}, false
+	}
+	if monitors == nil {
+		if monitors, err = GetMonitors(); err != nil {
+			log.Println("DEBUG: Error getting monitors:", err)
+			return rules.Exclusion{}, false
+		}
+	}
+	return Exclusions.Find(rules.Window{Exe: info.Exe, Class: info.Class, Title: info.Title}, len(monitors))
+}
+
+// excluded reports if an action has to leave the window alone, monitors are passed to exclusionFor.
+// Windows with AllowMove are only excluded from actions that resize them.
+func excluded(hwnd Handle, monitors []Monitor, resize bool) bool {
+	exclusion, ok := exclusionFor(hwnd, monitors)
+	if !ok || (exclusion.AllowMove && !resize) {
+		return false
+	}
+	if exclusion.AllowMove {
+		log.Printf("DEBUG: Window %v may only be moved, ignoring action.\n", hwnd)
+	} else {
+		log.Printf("DEBUG: Window %v is excluded, ignoring action.\n", hwnd)
+	}
+	return true
+}
+
+// resizable reports if actions may resize the window
+func resizable(hwnd Handle, monitors []Monitor) bool {
+	_, ok := exclusionFor(hwnd, monitors)
+	return !ok
+}
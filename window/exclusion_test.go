@@ -0,0 +1,79 @@
+package window
+
+import (
+	"telewindow/placement"
+	"telewindow/rules"
+	"testing"
+)
+
+// useExclusions excludes the windows for the duration of the test
+func useExclusions(t *testing.T, exclusions ...rules.Exclusion) {
+	t.Helper()
+	compiled, err := rules.NewExclusions(exclusions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := Exclusions
+	Exclusions = compiled
+	t.Cleanup(func() {
+		Exclusions = previous
+	})
+}
+
+func TestExcludedWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func()
+		want   RECT
+	}{
+		{"move", func() { MoveActiveWindow(placement.Right) }, rect(100, 100, 1060, 640)},
+		{"split", func() { SplitActiveWindow(placement.Left) }, rect(100, 100, 1060, 640)},
+		{"nudge", func() { NudgeActiveWindow(placement.Right) }, rect(100, 100, 1060, 640)},
+		{"maximize", ToggleMaximizeActiveWindow, rect(100, 100, 1060, 640)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			useExclusions(t, rules.Exclusion{Match: rules.Match{Exe: "mstsc.exe"}})
+			fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+			fake.AddMonitor(rect(1920, 0, 4480, 1440), rect(1920, 0, 4480, 1440))
+			hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+			fake.SetWindowInfo(hwnd, WindowInfo{Exe: "MSTSC.EXE", Class: "TscShellContainerClass"})
+
+			tt.action()
+			assertRect(t, fake, hwnd, tt.want)
+			if w, _ := fake.Window(hwnd); w.ShowCmd != SW_SHOWNORMAL {
+				t.Errorf("show state = %d, want normal", w.ShowCmd)
+			}
+		})
+	}
+}
+
+func TestMoveOnlyWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func()
+		want   RECT
+	}{
+		{"move keeps the size", func() { MoveActiveWindow(placement.Right) }, rect(2020, 100, 2980, 640)},
+		{"nudge", func() { NudgeActiveWindow(placement.Right) }, rect(150, 100, 1110, 640)},
+		{"center", CenterActiveWindow, rect(480, 270, 1440, 810)},
+		{"split resizes", func() { SplitActiveWindow(placement.Left) }, rect(100, 100, 1060, 640)},
+		{"grow resizes", func() { ResizeActiveWindowEdge(placement.Right, true) }, rect(100, 100, 1060, 640)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			useExclusions(t, rules.Exclusion{Match: rules.Match{Class: "UnrealWindow"}, AllowMove: true})
+			fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+			fake.AddMonitor(rect(1920, 0, 4480, 1440), rect(1920, 0, 4480, 1440))
+			hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+			fake.SetWindowInfo(hwnd, WindowInfo{Exe: "game.exe", Class: "UnrealWindow"})
+
+			tt.action()
+			assertRect(t, fake, hwnd, tt.want)
+		})
+	}
+}
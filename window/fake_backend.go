@@ -0,0 +1,377 @@
+package window
+
+import (
+	"fmt"
+	"sync"
+	"telewindow/placement"
+)
+
+// FakeWindow is a top-level window on the FakeBackend desktop
+type FakeWindow struct {
+	Rect    RECT
+	ShowCmd uint32
+	// Frame is the size of the invisible resize borders included in Rect
+	Frame placement.Frame
+	// MaximizedFrame is the size of the invisible borders while the window is maximized, none by default
+	MaximizedFrame placement.Frame
+	Info           WindowInfo
+	// Owner is the window owning this window, owned windows are not returned by Windows
+	Owner Handle
+	// normal is the restored rect while the window is maximized or minimized
+	normal RECT
+}
+
+// FakeBackend is an in-memory desktop implementing Backend.
+// It models monitors and windows without touching the OS so the window actions can be tested on any platform.
+type FakeBackend struct {
+	mu       sync.Mutex
+	monitors []Monitor
+	windows  map[Handle]*FakeWindow
+	// order is the z-order of the windows, topmost first
+	order  []Handle
+	active Handle
+	next   Handle
+}
+
+func NewFakeBackend() *FakeBackend {
+	return &FakeBackend{
+		windows: make(map[Handle]*FakeWindow),
+		next:    1,
+	}
+}
+
+// AddMonitor adds a monitor with the given bounds and work area and returns its handle
+func (f *FakeBackend) AddMonitor(rcMonitor RECT, rcWork RECT) Handle {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	mi := MONITORINFO{
+		RCMonitor: rcMonitor,
+		RCWork:    rcWork,
+	}
+	if len(f.monitors) == 0 {
+		mi.DwFlags = MONITORINFOF_PRIMARY
+	}
+	hMonitor := f.nextHandle()
+	f.monitors = append(f.monitors, Monitor{
+		HMonitor: hMonitor,
+		Name:     fmt.Sprintf(`\\.\DISPLAY%d`, len(f.monitors)+1),
+		Info:     mi,
+		Center:   calculateMonitorCenter(mi),
+		DPI:      96,
+	})
+	return hMonitor
+}
+
+// SetMonitorDPI changes the effective DPI of the monitor
+func (f *FakeBackend) SetMonitorDPI(hMonitor Handle, dpi uint32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.monitors {
+		if f.monitors[i].HMonitor == hMonitor {
+			f.monitors[i].DPI = dpi
+		}
+	}
+}
+
+// RemoveMonitor disconnects the monitor, the first remaining monitor becomes primary if it was the primary monitor
+func (f *FakeBackend) RemoveMonitor(hMonitor Handle) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.monitors {
+		if f.monitors[i].HMonitor == hMonitor {
+			primary := f.monitors[i].Info.DwFlags&MONITORINFOF_PRIMARY != 0
+			f.monitors = append(f.monitors[:i], f.monitors[i+1:]...)
+			if primary && len(f.monitors) > 0 {
+				f.monitors[0].Info.DwFlags |= MONITORINFOF_PRIMARY
+			}
+			return
+		}
+	}
+}
+
+// AddWindow adds a normal window on top of the z-order, makes it the active window and returns its handle
+func (f *FakeBackend) AddWindow(rect RECT) Handle {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hwnd := f.nextHandle()
+	f.windows[hwnd] = &FakeWindow{
+		Rect:    rect,
+		ShowCmd: SW_SHOWNORMAL,
+		normal:  rect,
+	}
+	f.order = append([]Handle{hwnd}, f.order...)
+	f.active = hwnd
+	return hwnd
+}
+
+// SetWindowFrame sets the invisible resize borders of the window
+func (f *FakeBackend) SetWindowFrame(hwnd Handle, frame placement.Frame) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if w, ok := f.windows[hwnd]; ok {
+		w.Frame = frame
+	}
+}
+
+// SetWindowInfo sets the executable, class and title of the window
+func (f *FakeBackend) SetWindowInfo(hwnd Handle, info WindowInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if w, ok := f.windows[hwnd]; ok {
+		w.Info = info
+	}
+}
+
+// SetWindowOwner makes the window an owned window of the owner, like a tool window or dialog
+func (f *FakeBackend) SetWindowOwner(hwnd, owner Handle) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if w, ok := f.windows[hwnd]; ok {
+		w.Owner = owner
+	}
+}
+
+// RemoveWindow closes the window
+func (f *FakeBackend) RemoveWindow(hwnd Handle) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.windows, hwnd)
+	for i, h := range f.order {
+		if h == hwnd {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+	if f.active == hwnd {
+		f.active = 0
+		if len(f.order) > 0 {
+			f.active = f.order[0]
+		}
+	}
+}
+
+// SetActiveWindow brings the window to the top of the z-order and makes it the active window
+func (f *FakeBackend) SetActiveWindow(hwnd Handle) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.windows[hwnd]; !ok {
+		return fmt.Errorf("unknown window: %v", hwnd)
+	}
+	f.activate(hwnd)
+	return nil
+}
+
+// activate brings the window to the top of the z-order and makes it the active window, f.mu has to be held
+func (f *FakeBackend) activate(hwnd Handle) {
+	for i, h := range f.order {
+		if h == hwnd {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+	f.order = append([]Handle{hwnd}, f.order...)
+	f.active = hwnd
+}
+
+// Window returns a copy of the window state
+func (f *FakeBackend) Window(hwnd Handle) (FakeWindow, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return FakeWindow{}, false
+	}
+	return *w, true
+}
+
+func (f *FakeBackend) ActiveWindow() (Handle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == 0 {
+		return 0, fmt.Errorf("no active window")
+	}
+	return f.active, nil
+}
+
+// FocusWindow makes the window the active window
+func (f *FakeBackend) FocusWindow(hwnd Handle) error {
+	return f.SetActiveWindow(hwnd)
+}
+
+func (f *FakeBackend) WindowRect(hwnd Handle) (*RECT, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return nil, fmt.Errorf("unknown window: %v", hwnd)
+	}
+	rect := w.Rect
+	return &rect, nil
+}
+
+func (f *FakeBackend) Windows() ([]Handle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var handles []Handle
+	for _, hwnd := range f.order {
+		if w := f.windows[hwnd]; w.ShowCmd != SW_SHOWMINIMIZED && w.Owner == 0 {
+			handles = append(handles, hwnd)
+		}
+	}
+	return handles, nil
+}
+
+func (f *FakeBackend) ProcessWindows(pid uint32) ([]Handle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var handles []Handle
+	for _, hwnd := range f.order {
+		if f.windows[hwnd].ShowCmd == SW_SHOWMINIMIZED {
+			continue
+		}
+		// The window at the end of the owner chain has to be an application window of the process
+		root := f.windows[hwnd]
+		for root.Owner != 0 && f.windows[root.Owner] != nil {
+			root = f.windows[root.Owner]
+		}
+		if root.Owner == 0 && root.ShowCmd != SW_SHOWMINIMIZED && root.Info.ProcessID == pid {
+			handles = append(handles, hwnd)
+		}
+	}
+	return handles, nil
+}
+
+func (f *FakeBackend) ProcessID(hwnd Handle) (uint32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return 0, fmt.Errorf("unknown window: %v", hwnd)
+	}
+	return w.Info.ProcessID, nil
+}
+
+func (f *FakeBackend) WindowInfo(hwnd Handle) (WindowInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return WindowInfo{}, fmt.Errorf("unknown window: %v", hwnd)
+	}
+	return w.Info, nil
+}
+
+func (f *FakeBackend) IsWindow(hwnd Handle) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.windows[hwnd]
+	return ok
+}
+
+func (f *FakeBackend) VisibleRect(hwnd Handle) (*RECT, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return nil, fmt.Errorf("unknown window: %v", hwnd)
+	}
+	frame := w.Frame
+	if w.ShowCmd == SW_SHOWMAXIMIZED {
+		frame = w.MaximizedFrame
+	}
+	rect := RECT(frame.Visible(placement.Rect(w.Rect)))
+	return &rect, nil
+}
+
+func (f *FakeBackend) ShowState(hwnd Handle) (uint32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return 0, fmt.Errorf("unknown window: %v", hwnd)
+	}
+	return w.ShowCmd, nil
+}
+
+func (f *FakeBackend) ShowWindow(hwnd Handle, cmd int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return fmt.Errorf("unknown window: %v", hwnd)
+	}
+
+	if w.ShowCmd == SW_SHOWNORMAL {
+		w.normal = w.Rect
+	}
+
+	switch cmd {
+	case SW_MAXIMIZE:
+		// Maximize to the work area of the monitor the window is on, like Windows this activates the window
+		if m := findCurrentMonitor(f.monitors, &w.normal); m != nil {
+			w.Rect = m.Info.RCWork
+		}
+		w.ShowCmd = SW_SHOWMAXIMIZED
+		f.activate(hwnd)
+	case SW_RESTORE, SW_SHOWNORMAL:
+		w.Rect = w.normal
+		w.ShowCmd = SW_SHOWNORMAL
+	case SW_SHOWMINIMIZED:
+		// Windows parks minimized windows far outside of the desktop
+		w.Rect = RECT{Left: -32000, Top: -32000, Right: -32000 + 160, Bottom: -32000 + 28}
+		w.ShowCmd = SW_SHOWMINIMIZED
+	default:
+		return fmt.Errorf("unsupported show command: %d", cmd)
+	}
+	return nil
+}
+
+func (f *FakeBackend) SetWindowRect(hwnd Handle, rect RECT) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w, ok := f.windows[hwnd]
+	if !ok {
+		return fmt.Errorf("unknown window: %v", hwnd)
+	}
+	w.Rect = rect
+	if w.ShowCmd == SW_SHOWNORMAL {
+		w.normal = rect
+	}
+	return nil
+}
+
+func (f *FakeBackend) Monitors() ([]Monitor, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	monitors := make([]Monitor, len(f.monitors))
+	copy(monitors, f.monitors)
+	return monitors, nil
+}
+
+func (f *FakeBackend) nextHandle() Handle {
+	h := f.next
+	f.next++
+	return h
+}
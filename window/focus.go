@@ -0,0 +1,51 @@
+package window
+
+import (
+	"log"
+	"telewindow/placement"
+)
+
+// FocusWindowInDirection activates the nearest window in the direction of the active window, also on other monitors.
+// The windows are not moved.
+func FocusWindowInDirection(direction int) {
+	log.Printf("DEBUG: Entering FocusWindowInDirection() with direction: %d\n", direction)
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	rect, _, err := getVisibleWindowRect(activeWindow)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return
+	}
+
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return
+	}
+	var candidates []Handle
+	var rects []placement.Rect
+	for _, hwnd := range handles {
+		if hwnd == activeWindow {
+			continue
+		}
+		r, _, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, hwnd)
+		rects = append(rects, placement.Rect(*r))
+	}
+
+	i := placement.NearestInDirection(placement.Rect(*rect), rects, direction)
+	if i < 0 {
+		log.Println("DEBUG: No window found in the desired direction.")
+		return
+	}
+	log.Printf("DEBUG: Focusing window %v at %+v\n", candidates[i], rects[i])
+	if err := backend.FocusWindow(candidates[i]); err != nil {
+		log.Println("DEBUG: Error focusing window:", err)
+	}
+}
@@ -0,0 +1,35 @@
+package window
+
+import (
+	"telewindow/placement"
+	"testing"
+)
+
+func TestFocusWindowInDirection(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	other := fake.AddWindow(rect(1920, 0, 3840, 1040))
+	bottomRight := fake.AddWindow(rect(960, 520, 1920, 1040))
+	topRight := fake.AddWindow(rect(960, 0, 1920, 520))
+	leftHalf := fake.AddWindow(rect(0, 0, 960, 1040))
+
+	steps := []struct {
+		direction int
+		want      Handle
+	}{
+		{placement.Right, topRight},
+		{placement.Down, bottomRight},
+		{placement.Right, other},
+		{placement.Up, other}, // The quarters are to the left, not above
+		{placement.Left, bottomRight},
+		{placement.Left, leftHalf},
+	}
+	for _, step := range steps {
+		FocusWindowInDirection(step.direction)
+		if active, _ := fake.ActiveWindow(); active != step.want {
+			t.Fatalf("after focusing %d the active window is %v, want %v", step.direction, active, step.want)
+		}
+	}
+	assertRect(t, fake, topRight, rect(960, 0, 1920, 520))
+}
@@ -0,0 +1,208 @@
+package window
+
+import (
+	"log"
+	"slices"
+	"telewindow/placement"
+)
+
+// gatherOptions returns the placement options for gathering and scattering windows,
+// windows are always scaled proportionally to the size of the monitors
+func gatherOptions() placement.Options {
+	opts := placementOptions()
+	opts.Mode = placement.Percentage
+	if opts.Clamp == placement.ClampNone {
+		opts.Clamp = placement.ClampShrink
+	}
+	return opts
+}
+
+// GatherWindows moves all visible top-level windows onto the monitor of the active window (or the primary monitor),
+// keeping their size and position relative to the monitor. Windows that are not on any monitor are centered.
+func GatherWindows() {
+	log.Println("DEBUG: Entering GatherWindows()")
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+
+	targetMonitor := activeOrPrimaryMonitor(monitors)
+	if targetMonitor == nil {
+		log.Println("DEBUG: Target monitor not found.")
+		return
+	}
+	log.Printf("DEBUG: Target monitor: %+v\n", targetMonitor.Info.RCMonitor)
+
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return
+	}
+
+	opts := gatherOptions()
+	for _, hwnd := range handles {
+		if excluded(hwnd, monitors, false) {
+			continue
+		}
+		rect, _, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			log.Println("DEBUG: Error getting window rect:", err)
+			continue
+		}
+		currentMonitor := findCurrentMonitor(monitors, rect)
+		if currentMonitor == nil {
+			log.Printf("DEBUG: Window %v is not on any monitor, centering it.\n", hwnd)
+			centerWindow(hwnd, targetMonitor)
+			continue
+		}
+		if currentMonitor.HMonitor == targetMonitor.HMonitor {
+			continue
+		}
+		moveWindow(hwnd, monitors, currentMonitor, targetMonitor, opts)
+	}
+	log.Println("DEBUG: Windows gathered successfully.")
+}
+
+// ScatterWindows rebalances the visible top-level windows across all monitors.
+// Windows are taken from the bottom of the z-order of the most crowded monitors until every monitor has about the same number of windows.
+func ScatterWindows() {
+	log.Println("DEBUG: Entering ScatterWindows()")
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+	monitors = sortMonitors(monitors)
+
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return
+	}
+	handles = slices.DeleteFunc(handles, func(hwnd Handle) bool {
+		return excluded(hwnd, monitors, false)
+	})
+
+	// Find the monitor of each window, -1 for windows that are not on any monitor
+	current := make([]int, len(handles))
+	for i, hwnd := range handles {
+		current[i] = -1
+		rect, _, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			continue
+		}
+		if m := findCurrentMonitor(monitors, rect); m != nil {
+			for j := range monitors {
+				if monitors[j].HMonitor == m.HMonitor {
+					current[i] = j
+				}
+			}
+		}
+	}
+
+	opts := gatherOptions()
+	for i, target := range scatterPlan(current, len(monitors)) {
+		if target == current[i] {
+			continue
+		}
+		if current[i] < 0 {
+			centerWindow(handles[i], &monitors[target])
+			continue
+		}
+		moveWindow(handles[i], monitors, &monitors[current[i]], &monitors[target], opts)
+	}
+	log.Println("DEBUG: Windows scattered successfully.")
+}
+
+// scatterPlan returns the monitor index for each window given the index of its current monitor (-1 for none).
+// The windows are in z-order, topmost first. Windows without a monitor go to the least crowded monitor,
+// then windows from the bottom of the z-order are moved from the most to the least crowded monitor until the counts differ by at most one.
+func scatterPlan(current []int, monitorCount int) []int {
+	plan := make([]int, len(current))
+	copy(plan, current)
+	if monitorCount == 0 {
+		return plan
+	}
+
+	counts := make([]int, monitorCount)
+	for _, m := range plan {
+		if m >= 0 && m < monitorCount {
+			counts[m]++
+		}
+	}
+	leastCrowded := func() int {
+		least := 0
+		for m := range counts {
+			if counts[m] < counts[least] {
+				least = m
+			}
+		}
+		return least
+	}
+
+	for i, m := range plan {
+		if m < 0 || m >= monitorCount {
+			plan[i] = leastCrowded()
+			counts[plan[i]]++
+		}
+	}
+
+	moved := make([]bool, len(plan))
+	for {
+		most := 0
+		for m := range counts {
+			if counts[m] > counts[most] {
+				most = m
+			}
+		}
+		least := leastCrowded()
+		if counts[most]-counts[least] <= 1 {
+			return plan
+		}
+		for i := len(plan) - 1; i >= 0; i-- {
+			if plan[i] == most && !moved[i] {
+				plan[i] = least
+				moved[i] = true
+				counts[most]--
+				counts[least]++
+				break
+			}
+		}
+	}
+}
+
+// activeOrPrimaryMonitor returns the monitor of the active window, or the primary monitor if there is no active window
+func activeOrPrimaryMonitor(monitors []Monitor) *Monitor {
+	if activeWindow, err := GetActiveWindow(); err == nil {
+		if rect, _, err := getVisibleWindowRect(activeWindow); err == nil {
+			if m := findCurrentMonitor(monitors, rect); m != nil {
+				return m
+			}
+		}
+	}
+	for i := range monitors {
+		if monitors[i].Info.DwFlags&MONITORINFOF_PRIMARY != 0 {
+			return &monitors[i]
+		}
+	}
+	if len(monitors) > 0 {
+		return &monitors[0]
+	}
+	return nil
+}
+
+// centerWindow centers the window on the monitor, shrinking it to fit the monitor
+func centerWindow(hwnd Handle, monitor *Monitor) {
+	rect, frame, err := getVisibleWindowRect(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return
+	}
+	recordWindowState(hwnd)
+	newRect := placement.Center(placement.Rect(*rect), monitor.placement().Area(UseWorkArea))
+	err = setWindowRect(hwnd, RECT(frame.Window(newRect)))
+	if err != nil {
+		log.Println("DEBUG: MoveWindow failed:", err)
+	}
+}
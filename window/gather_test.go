@@ -0,0 +1,52 @@
+package window
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScatterPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []int
+		monitors int
+		want     []int
+	}{
+		{"all on one monitor", []int{0, 0, 0, 0}, 2, []int{0, 0, 1, 1}},
+		{"bottom windows move first", []int{0, 0, 0, 0, 0}, 3, []int{0, 0, 1, 2, 1}},
+		{"already balanced", []int{0, 1, 0, 1}, 2, []int{0, 1, 0, 1}},
+		{"off-screen windows go to the least crowded monitor", []int{0, -1, 0}, 2, []int{0, 1, 0}},
+		{"no monitors", []int{-1}, 0, []int{-1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scatterPlan(tt.current, tt.monitors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("scatterPlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGatherAndScatterWindows(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 5760, 2160), rect(1920, 0, 5760, 2160))
+	big := fake.AddWindow(rect(2120, 200, 4040, 1280))
+	stranded := fake.AddWindow(rect(-3000, 100, -2000, 700))
+	laptop := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	GatherWindows()
+
+	// Windows are scaled to the monitor of the active window, off-screen windows are centered
+	assertRect(t, fake, big, rect(100, 100, 1060, 640))
+	assertRect(t, fake, stranded, rect(460, 240, 1460, 840))
+	assertRect(t, fake, laptop, rect(100, 100, 1060, 640))
+
+	ScatterWindows()
+
+	// The bottom window goes back to the second monitor
+	assertRect(t, fake, big, rect(2120, 200, 4040, 1280))
+	assertRect(t, fake, stranded, rect(460, 240, 1460, 840))
+	assertRect(t, fake, laptop, rect(100, 100, 1060, 640))
+}
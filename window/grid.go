@@ -0,0 +1,66 @@
+package window
+
+import (
+	"log"
+	"telewindow/placement"
+)
+
+// DefaultGrid is the grid used for monitors without a grid in Grids
+var DefaultGrid = placement.Grid{Columns: 2, Rows: 2}
+
+// Grids holds the grid per monitor index, alias or device name
+var Grids = map[string]placement.Grid{}
+
+// gridForMonitor returns the grid configured for the monitor
+func gridForMonitor(m *Monitor) placement.Grid {
+	// Monitor indexes depend on all connected monitors
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+	}
+	if grid, exists := monitorSetting(monitors, m, Grids); exists && grid.Valid() {
+		return grid
+	}
+	if DefaultGrid.Valid() {
+		return DefaultGrid
+	}
+	return placement.Grid{Columns: 1, Rows: 1}
+}
+
+// GridSnapActiveWindow snaps the active window to the grid cell under its center
+func GridSnapActiveWindow() {
+	log.Println("DEBUG: Entering GridSnapActiveWindow()")
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		grid := gridForMonitor(monitor)
+		cell := grid.CellAt(area, window)
+		log.Printf("DEBUG: Grid %+v, cell %+v\n", grid, cell)
+		return grid.Rect(area, cell), true
+	})
+}
+
+// GridMoveActiveWindow moves the active window one grid cell in the direction
+func GridMoveActiveWindow(direction int) {
+	log.Println("DEBUG: Entering GridMoveActiveWindow() with direction:", direction)
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		grid := gridForMonitor(monitor)
+		span := grid.Move(grid.SpanOf(area, window), direction)
+		log.Printf("DEBUG: Grid %+v, span %+v\n", grid, span)
+		return grid.Rect(area, span), true
+	})
+}
+
+// GridResizeActiveWindow grows or shrinks the grid span of the active window on the side of the direction
+func GridResizeActiveWindow(direction int, grow bool) {
+	log.Println("DEBUG: Entering GridResizeActiveWindow() with direction:", direction, "grow:", grow)
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		grid := gridForMonitor(monitor)
+		span := grid.SpanOf(area, window)
+		if grow {
+			span = grid.Grow(span, direction)
+		} else {
+			span = grid.Shrink(span, direction)
+		}
+		log.Printf("DEBUG: Grid %+v, span %+v\n", grid, span)
+		return grid.Rect(area, span), true
+	})
+}
@@ -0,0 +1,43 @@
+package window
+
+import (
+	"telewindow/placement"
+	"testing"
+)
+
+func TestGridActions(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := Grids
+	Grids = map[string]placement.Grid{"2": {Columns: 3, Rows: 2}}
+	t.Cleanup(func() {
+		Grids = previous
+	})
+	fake.AddMonitor(rect(0, 0, 2000, 1000), rect(0, 0, 2000, 1000))
+	fake.AddMonitor(rect(2000, 0, 5000, 1000), rect(2000, 0, 5000, 1000))
+
+	// The first monitor uses the default 2x2 grid
+	first := fake.AddWindow(rect(1100, 600, 1500, 900))
+	GridSnapActiveWindow()
+	assertRect(t, fake, first, rect(1000, 500, 2000, 1000))
+
+	// The second monitor uses its own 3x2 grid
+	second := fake.AddWindow(rect(2100, 100, 2500, 300))
+	GridSnapActiveWindow()
+	assertRect(t, fake, second, rect(2000, 0, 3000, 500))
+
+	GridMoveActiveWindow(1)
+	assertRect(t, fake, second, rect(3000, 0, 4000, 500))
+
+	GridResizeActiveWindow(1, true)
+	assertRect(t, fake, second, rect(3000, 0, 5000, 500))
+
+	GridResizeActiveWindow(2, true)
+	assertRect(t, fake, second, rect(3000, 0, 5000, 1000))
+
+	GridResizeActiveWindow(-1, false)
+	assertRect(t, fake, second, rect(4000, 0, 5000, 1000))
+
+	// Moving stops at the edge of the grid
+	GridMoveActiveWindow(1)
+	assertRect(t, fake, second, rect(4000, 0, 5000, 1000))
+}
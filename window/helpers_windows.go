@@ -0,0 +1,59 @@
+package window
+
+import (
+	"os"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+func RelaunchAsAdmin() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	verb := "runas"
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	args := strings.Join(os.Args[1:], " ")
+
+	operation, _ := syscall.UTF16PtrFromString(verb)
+	file, _ := syscall.UTF16PtrFromString(exe)
+	parameters, _ := syscall.UTF16PtrFromString(args)
+	directory, _ := syscall.UTF16PtrFromString(cwd)
+	showCmd := int32(1) // SW_NORMAL
+
+	err = windows.ShellExecute(0, operation, file, parameters, directory, showCmd)
+	if err != nil && err.Error() != "The operation completed successfully." {
+		return err
+	}
+
+	return nil
+}
+
+func IsRunningAsAdmin() bool {
+	var sid *windows.SID
+	err := windows.AllocateAndInitializeSid(
+		&windows.SECURITY_NT_AUTHORITY,
+		2,
+		windows.SECURITY_BUILTIN_DOMAIN_RID,
+		windows.DOMAIN_ALIAS_RID_ADMINS,
+		0, 0, 0, 0, 0, 0,
+		&sid)
+	if err != nil {
+		return false
+	}
+	defer windows.FreeSid(sid)
+
+	token := windows.Token(0)
+	member, err := token.IsMember(sid)
+	if err != nil {
+		return false
+	}
+	return member
+}
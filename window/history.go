@@ -0,0 +1,162 @@
+package window
+
+import (
+	"log"
+	"sync"
+	"telewindow/placement"
+)
+
+// HistoryLimit is the number of actions that can be undone per window, ApplyConfig keeps it at least 1
+var HistoryLimit = 20
+
+// windowState is the geometry and show state of a window
+type windowState struct {
+	Rect    RECT
+	ShowCmd uint32
+}
+
+// windowHistory holds the states to go back to (undo) and forward to (redo), most recent last
+type windowHistory struct {
+	undo []windowState
+	redo []windowState
+}
+
+var (
+	historyMu sync.Mutex
+	histories = map[Handle]*windowHistory{}
+)
+
+// getWindowState returns the current geometry and show state of the window
+func getWindowState(hwnd Handle) (windowState, error) {
+	rect, err := backend.WindowRect(hwnd)
+	if err != nil {
+		return windowState{}, err
+	}
+	showCmd, err := backend.ShowState(hwnd)
+	if err != nil {
+		return windowState{}, err
+	}
+	return windowState{Rect: *rect, ShowCmd: showCmd}, nil
+}
+
+// recordWindowState saves the current state of the window before an action changes it
+func recordWindowState(hwnd Handle) {
+	state, err := getWindowState(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error recording window state:", err)
+		return
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	pruneHistories()
+	h, exists := histories[hwnd]
+	if !exists {
+		h = &windowHistory{}
+		histories[hwnd] = h
+	}
+	h.undo = pushState(h.undo, state)
+	h.redo = nil
+}
+
+// pushState appends the state and drops the oldest states above HistoryLimit
+func pushState(states []windowState, state windowState) []windowState {
+	states = append(states, state)
+	if HistoryLimit > 0 && len(states) > HistoryLimit {
+		states = states[len(states)-HistoryLimit:]
+	}
+	return states
+}
+
+// pruneHistories drops the history of windows that no longer exist
+func pruneHistories() {
+	for hwnd := range histories {
+		if !backend.IsWindow(hwnd) {
+			log.Printf("DEBUG: Dropping history of closed window %v\n", hwnd)
+			delete(histories, hwnd)
+		}
+	}
+}
+
+// UndoActiveWindow reverts the last action on the active window
+func UndoActiveWindow() {
+	log.Println("DEBUG: Entering UndoActiveWindow()")
+	stepActiveWindowHistory(true)
+}
+
+// RedoActiveWindow repeats the last undone action on the active window
+func RedoActiveWindow() {
+	log.Println("DEBUG: Entering RedoActiveWindow()")
+	stepActiveWindowHistory(false)
+}
+
+// stepActiveWindowHistory moves the active window one state back (undo) or forward in its history
+func stepActiveWindowHistory(undo bool) {
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	if excluded(activeWindow, nil, false) {
+		return
+	}
+
+	current, err := getWindowState(activeWindow)
+	if err != nil {
+		log.Println("DEBUG: Error getting window state:", err)
+		return
+	}
+
+	historyMu.Lock()
+	pruneHistories()
+	h, exists := histories[activeWindow]
+	if !exists {
+		historyMu.Unlock()
+		log.Println("DEBUG: No history for the active window.")
+		return
+	}
+	from, to := &h.undo, &h.redo
+	if !undo {
+		from, to = to, from
+	}
+	if len(*from) == 0 {
+		historyMu.Unlock()
+		log.Println("DEBUG: Nothing to undo or redo.")
+		return
+	}
+	state := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = pushState(*to, current)
+	historyMu.Unlock()
+
+	applyWindowState(activeWindow, state)
+}
+
+// applyWindowState restores the geometry and show state of the window
+func applyWindowState(hwnd Handle, state windowState) {
+	log.Printf("DEBUG: Applying window state: %+v\n", state)
+	maximized, err := IsActiveWindowMaximized(&hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error checking if window is maximized:", err)
+		return
+	}
+	if maximized {
+		RestoreActiveWindow(&hwnd)
+	}
+
+	rect := state.Rect
+	if state.ShowCmd == SW_SHOWMAXIMIZED {
+		// Place the restored window within the monitor it is maximized on
+		rect = RECT(placement.Shrink(placement.Rect(rect), 0.02))
+	}
+	err = setWindowRect(hwnd, rect)
+	if err != nil {
+		log.Println("DEBUG: MoveWindow failed:", err)
+		return
+	}
+
+	if state.ShowCmd == SW_SHOWMAXIMIZED {
+		MaximizeActiveWindow(&hwnd)
+	}
+}
@@ -0,0 +1,86 @@
+package window
+
+import "testing"
+
+func TestUndoRedoActiveWindow(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	MoveActiveWindow(1)
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(1920, 0, 2880, 1040))
+
+	UndoActiveWindow()
+	assertRect(t, fake, hwnd, rect(2020, 100, 2980, 640))
+	UndoActiveWindow()
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+
+	// Nothing left to undo
+	UndoActiveWindow()
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+
+	RedoActiveWindow()
+	assertRect(t, fake, hwnd, rect(2020, 100, 2980, 640))
+
+	// A new action discards the redo history
+	SplitActiveWindow(1)
+	RedoActiveWindow()
+	assertRect(t, fake, hwnd, rect(2880, 0, 3840, 1040))
+}
+
+func TestUndoMaximize(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	ToggleMaximizeActiveWindow()
+	MoveActiveWindow(1)
+	assertRect(t, fake, hwnd, rect(1920, 0, 3840, 1040))
+
+	// The window is maximized again on the first monitor
+	UndoActiveWindow()
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 1040))
+	if w, _ := fake.Window(hwnd); w.ShowCmd != SW_SHOWMAXIMIZED {
+		t.Errorf("show state = %d, want maximized", w.ShowCmd)
+	}
+
+	UndoActiveWindow()
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+	if w, _ := fake.Window(hwnd); w.ShowCmd != SW_SHOWNORMAL {
+		t.Errorf("show state = %d, want normal", w.ShowCmd)
+	}
+}
+
+func TestHistoryBoundedAndPruned(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := HistoryLimit
+	HistoryLimit = 2
+	t.Cleanup(func() {
+		HistoryLimit = previous
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	closed := fake.AddWindow(rect(100, 100, 1060, 640))
+	SplitActiveWindow(-1)
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	SplitActiveWindow(-1)
+	SplitActiveWindow(1)
+	SplitActiveWindow(-2)
+	UndoActiveWindow()
+	UndoActiveWindow()
+	UndoActiveWindow()
+	// The first split was dropped from the bounded history
+	assertRect(t, fake, hwnd, rect(0, 0, 960, 1040))
+
+	fake.RemoveWindow(closed)
+	SplitActiveWindow(1)
+	historyMu.Lock()
+	_, exists := histories[closed]
+	historyMu.Unlock()
+	if exists {
+		t.Error("history of the closed window was not dropped")
+	}
+}
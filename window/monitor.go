@@ -1,27 +1,23 @@
 package window
 
 import (
-	"fmt"
 	"log"
 	"math"
-	"syscall"
-	"unsafe"
-
-	"golang.org/x/sys/windows"
-)
-
-var (
-	procGetForegroundWindow = user32.NewProc("GetForegroundWindow")
-	procGetWindowRect       = user32.NewProc("GetWindowRect")
-	procEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
-	procGetMonitorInfo      = user32.NewProc("GetMonitorInfoW")
+	"sort"
+	"strconv"
+	"strings"
+	"telewindow/placement"
 )
 
 // Monitor information
 type Monitor struct {
-	HMonitor windows.Handle
-	Info     MONITORINFO
-	Center   Point
+	HMonitor Handle
+	// Name is the device name of the monitor, e.g. \\.\DISPLAY1
+	Name   string
+	Info   MONITORINFO
+	Center Point
+	// DPI is the effective DPI of the monitor, 0 if unknown
+	DPI uint32
 }
 
 // Structures
@@ -40,6 +36,9 @@ type MONITORINFO struct {
 	DwFlags   uint32
 }
 
+// MONITORINFO flags
+const MONITORINFOF_PRIMARY = 0x1
+
 var directionVectors = map[int]Point{
 	-1: {X: -1, Y: 0}, // Left
 	1:  {X: 1, Y: 0},  // Right
@@ -53,6 +52,15 @@ func calculateMonitorCenter(mi MONITORINFO) Point {
 	return Point{X: centerX, Y: centerY}
 }
 
+// placement converts the monitor to the platform independent description used by the placement package
+func (m *Monitor) placement() placement.Monitor {
+	return placement.Monitor{
+		Bounds:   placement.Rect(m.Info.RCMonitor),
+		WorkArea: placement.Rect(m.Info.RCWork),
+		DPI:      m.DPI,
+	}
+}
+
 func calculateOverlap(windowRect *RECT, monitorRect *RECT) int64 {
 	left := max(windowRect.Left, monitorRect.Left)
 	top := max(windowRect.Top, monitorRect.Top)
@@ -67,71 +75,219 @@ func calculateOverlap(windowRect *RECT, monitorRect *RECT) int64 {
 
 func GetMonitors() ([]Monitor, error) {
 	log.Println("DEBUG: Entering GetMonitors()")
-	var monitors []Monitor
-
-	enumProc := syscall.NewCallback(func(hMonitor windows.Handle, hdcMonitor windows.Handle, lprcMonitor *RECT, lParam uintptr) uintptr {
-		log.Printf("DEBUG: Enumerating monitor: %v\n", hMonitor)
-		var mi MONITORINFO
-		mi.CbSize = uint32(unsafe.Sizeof(mi))
-		ret, _, _ := procGetMonitorInfo.Call(
-			uintptr(hMonitor),
-			uintptr(unsafe.Pointer(&mi)),
-		)
-		if ret == 0 {
-			log.Println("DEBUG: GetMonitorInfo failed, continuing enumeration")
-			return 1 // Continue enumeration
-		}
-		monitors = append(monitors, Monitor{
-			HMonitor: hMonitor,
-			Info:     mi,
-			Center:   calculateMonitorCenter(mi),
-		})
-		log.Printf("DEBUG: Added monitor: %+v\n", mi)
-		return 1 // Continue enumeration
-	})
-
-	ret, _, err := procEnumDisplayMonitors.Call(
-		0,
-		0,
-		enumProc,
-		0,
-	)
-	if ret == 0 {
-		log.Printf("DEBUG: EnumDisplayMonitors failed: %v\n", err)
-		return nil, fmt.Errorf("EnumDisplayMonitors failed: %v", err)
+	monitors, err := backend.Monitors()
+	if err != nil {
+		log.Printf("DEBUG: Enumerating monitors failed: %v\n", err)
+		return nil, err
 	}
 
 	log.Printf("DEBUG: GetMonitors() found %d monitors\n", len(monitors))
 	return monitors, nil
 }
 
-func GetActiveWindow() (windows.Handle, error) {
+func GetActiveWindow() (Handle, error) {
 	log.Println("DEBUG: Entering GetActiveWindow()")
-	ret, _, err := procGetForegroundWindow.Call()
-	if ret == 0 {
-		log.Printf("DEBUG: GetForegroundWindow failed: %v\n", err)
-		return 0, fmt.Errorf("GetForegroundWindow failed: %v", err)
+	hwnd, err := backend.ActiveWindow()
+	if err != nil {
+		log.Printf("DEBUG: Getting the active window failed: %v\n", err)
+		return 0, err
 	}
-	log.Printf("DEBUG: Active window handle: %v\n", windows.Handle(ret))
-	return windows.Handle(ret), nil
+	log.Printf("DEBUG: Active window handle: %v\n", hwnd)
+	return hwnd, nil
 }
 
-func GetWindowRectWrapper(hwnd windows.Handle) (*RECT, error) {
+func GetWindowRectWrapper(hwnd Handle) (*RECT, error) {
 	log.Printf("DEBUG: Entering GetWindowRectWrapper() for handle: %v\n", hwnd)
-	var rect RECT
-	ret, _, err := procGetWindowRect.Call(
-		uintptr(hwnd),
-		uintptr(unsafe.Pointer(&rect)),
-	)
-	if ret == 0 {
-		log.Printf("DEBUG: GetWindowRect failed: %v\n", err)
-		return nil, fmt.Errorf("GetWindowRect failed: %v", err)
+	rect, err := backend.WindowRect(hwnd)
+	if err != nil {
+		log.Printf("DEBUG: Getting the window rect failed: %v\n", err)
+		return nil, err
 	}
-	log.Printf("DEBUG: Window rect: %+v\n", rect)
-	return &rect, nil
+	log.Printf("DEBUG: Window rect: %+v\n", *rect)
+	return rect, nil
 }
 
+// getWindowFrame returns the invisible resize borders of the window.
+// Windows without a visible frame (e.g. when DWM is unavailable) have an empty frame.
+func getWindowFrame(hwnd Handle, rect *RECT) placement.Frame {
+	visible, err := backend.VisibleRect(hwnd)
+	if err != nil {
+		log.Printf("DEBUG: Getting the visible window rect failed: %v\n", err)
+		return placement.Frame{}
+	}
+	frame := placement.FrameOf(placement.Rect(*rect), placement.Rect(*visible))
+	log.Printf("DEBUG: Window frame: %+v\n", frame)
+	return frame
+}
+
+// getVisibleWindowRect returns the visible frame of the window and its invisible resize borders.
+// Windows are placed by their visible frame, not the invisible resize borders around it.
+func getVisibleWindowRect(hwnd Handle) (*RECT, placement.Frame, error) {
+	rect, err := GetWindowRectWrapper(hwnd)
+	if err != nil {
+		return nil, placement.Frame{}, err
+	}
+	frame := getWindowFrame(hwnd, rect)
+	visible := RECT(frame.Visible(placement.Rect(*rect)))
+	return &visible, frame, nil
+}
+
+// MonitorAliases maps user defined aliases to monitor device names
+var MonitorAliases = map[string]string{}
+
+// sortMonitors orders the monitors left-to-right, top-to-bottom
+func sortMonitors(monitors []Monitor) []Monitor {
+	sorted := make([]Monitor, len(monitors))
+	copy(sorted, monitors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Info.RCMonitor, sorted[j].Info.RCMonitor
+		if a.Left != b.Left {
+			return a.Left < b.Left
+		}
+		return a.Top < b.Top
+	})
+	return sorted
+}
+
+// resolveMonitor finds the monitor by alias, device name or index (1 is the leftmost monitor)
+func resolveMonitor(monitors []Monitor, target string) *Monitor {
+	name := target
+	if device, exists := MonitorAliases[target]; exists {
+		name = device
+	}
+	for _, monitor := range monitors {
+		if monitor.Name != "" && strings.EqualFold(monitor.Name, name) {
+			return &monitor
+		}
+	}
+
+	index, err := strconv.Atoi(target)
+	if err != nil {
+		return nil
+	}
+	sorted := sortMonitors(monitors)
+	if index < 1 || index > len(sorted) {
+		return nil
+	}
+	return &sorted[index-1]
+}
+
+// monitorSetting returns the setting of the monitor from settings keyed by monitor index, alias or device name.
+// If several keys refer to the monitor the device name wins over an alias and an alias over an index,
+// keys of the same kind are compared alphabetically.
+func monitorSetting[T any](monitors []Monitor, m *Monitor, settings map[string]T) (T, bool) {
+	rank := func(key string) int {
+		switch {
+		case strings.EqualFold(key, m.Name):
+			return 0
+		case MonitorAliases[key] != "":
+			return 1
+		}
+		return 2
+	}
+
+	var setting T
+	bestKey, found := "", false
+	for key, value := range settings {
+		if km := resolveMonitor(monitors, key); km == nil || km.HMonitor != m.HMonitor {
+			continue
+		}
+		if !found || rank(key) < rank(bestKey) || (rank(key) == rank(bestKey) && key < bestKey) {
+			setting, bestKey, found = value, key, true
+		}
+	}
+	return setting, found
+}
+
+// findCurrentMonitor returns the monitor with the largest overlap with the window
+func findCurrentMonitor(monitors []Monitor, rect *RECT) *Monitor {
+	var currentMonitor *Monitor
+	var maxOverlap int64 = 0
+
+	for _, m := range monitors {
+		overlap := calculateOverlap(rect, &m.Info.RCMonitor)
+		if overlap > maxOverlap {
+			maxOverlap = overlap
+			currentMonitor = &m
+		}
+	}
+	return currentMonitor
+}
+
+// Policies for MonitorFallback
+const (
+	// FallbackNone does nothing if there is no monitor in the direction
+	FallbackNone = "none"
+	// FallbackWrap wraps around to the farthest monitor in the opposite direction
+	FallbackWrap = "wrap"
+	// FallbackNearest picks the nearest monitor in any direction
+	FallbackNearest = "nearest"
+)
+
+// MonitorFallback controls which monitor is picked when there is no monitor in the requested direction
+var MonitorFallback = FallbackNone
+
+// findTargetMonitorWithFallback finds the monitor in the direction and applies MonitorFallback if there is none
+func findTargetMonitorWithFallback(monitors []Monitor, currentMonitor *Monitor, direction int) *Monitor {
+	if targetMonitor := findTargetMonitor(monitors, currentMonitor, direction); targetMonitor != nil {
+		return targetMonitor
+	}
+	if _, exists := directionVectors[direction]; !exists {
+		return nil
+	}
+
+	switch MonitorFallback {
+	case FallbackWrap:
+		log.Println("DEBUG: No monitor in the direction, wrapping around.")
+		if NeighborAlgorithm == NeighborCenter {
+			return farthestMonitor(monitorsInDirection(monitors, currentMonitor, -direction), currentMonitor)
+		}
+		return edgeNeighbor(monitors, currentMonitor, -direction, true)
+	case FallbackNearest:
+		log.Println("DEBUG: No monitor in the direction, picking the nearest monitor.")
+		var others []Monitor
+		for _, monitor := range monitors {
+			if monitor.HMonitor != currentMonitor.HMonitor {
+				others = append(others, monitor)
+			}
+		}
+		return nearestMonitor(others, currentMonitor)
+	}
+	return nil
+}
+
+// Algorithms for NeighborAlgorithm
+const (
+	// NeighborEdge picks the monitor sharing the edge in the direction
+	NeighborEdge = "edge"
+	// NeighborCenter picks the nearest monitor whose center is within 45 degrees of the direction
+	NeighborCenter = "center"
+)
+
+// NeighborAlgorithm controls how the monitor in a direction is found
+var NeighborAlgorithm = NeighborEdge
+
 func findTargetMonitor(monitors []Monitor, currentMonitor *Monitor, direction int) *Monitor {
+	if NeighborAlgorithm == NeighborCenter {
+		return findTargetMonitorByCenter(monitors, currentMonitor, direction)
+	}
+	return edgeNeighbor(monitors, currentMonitor, direction, false)
+}
+
+func findTargetMonitorByCenter(monitors []Monitor, currentMonitor *Monitor, direction int) *Monitor {
+	candidates := monitorsInDirection(monitors, currentMonitor, direction)
+
+	// If no candidates, return nil
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Find the nearest monitor among candidates
+	return nearestMonitor(candidates, currentMonitor)
+}
+
+// monitorsInDirection returns the monitors whose center lies within 45 degrees of the direction
+func monitorsInDirection(monitors []Monitor, currentMonitor *Monitor, direction int) []Monitor {
 	dirVec, exists := directionVectors[direction]
 	if !exists {
 		return nil
@@ -168,21 +324,79 @@ func findTargetMonitor(monitors []Monitor, currentMonitor *Monitor, direction in
 			candidates = append(candidates, monitor)
 		}
 	}
+	return candidates
+}
 
-	// If no candidates, return nil
-	if len(candidates) == 0 {
+// edgeNeighbor returns the monitor beyond the edge of the current monitor in the direction.
+// Monitors sharing part of the perpendicular extent are preferred, the one with the smallest gap wins (the largest if farthest is set)
+// and ties are broken by the larger shared extent. If no monitor shares any extent the nearest center is used.
+func edgeNeighbor(monitors []Monitor, currentMonitor *Monitor, direction int, farthest bool) *Monitor {
+	if _, exists := directionVectors[direction]; !exists {
 		return nil
 	}
 
-	// Find the nearest monitor among candidates
+	var candidates []Monitor
+	var targetMonitor *Monitor
+	var bestGap, bestOverlap int32
+
+	for _, monitor := range monitors {
+		if monitor.HMonitor == currentMonitor.HMonitor {
+			continue // Skip the current monitor
+		}
+
+		gap, overlap := edgeRelation(&currentMonitor.Info.RCMonitor, &monitor.Info.RCMonitor, direction)
+		if gap < 0 {
+			continue // Not beyond the edge
+		}
+		candidates = append(candidates, monitor)
+		if overlap <= 0 {
+			continue
+		}
+
+		better := targetMonitor == nil ||
+			(!farthest && gap < bestGap) ||
+			(farthest && gap > bestGap) ||
+			(gap == bestGap && overlap > bestOverlap)
+		if better {
+			bestGap = gap
+			bestOverlap = overlap
+			targetMonitor = &monitor
+		}
+	}
+
+	if targetMonitor != nil {
+		return targetMonitor
+	}
+	// Fall back to the distance between the centers
+	if farthest {
+		return farthestMonitor(candidates, currentMonitor)
+	}
+	return nearestMonitor(candidates, currentMonitor)
+}
+
+// edgeRelation returns the gap between the edge of the current rect in the direction and the opposite edge of the other rect
+// and how much the rects share of the perpendicular extent
+func edgeRelation(current, other *RECT, direction int) (gap int32, overlap int32) {
+	switch direction {
+	case -1: // Left
+		return current.Left - other.Right, min(current.Bottom, other.Bottom) - max(current.Top, other.Top)
+	case 1: // Right
+		return other.Left - current.Right, min(current.Bottom, other.Bottom) - max(current.Top, other.Top)
+	case -2: // Up
+		return current.Top - other.Bottom, min(current.Right, other.Right) - max(current.Left, other.Left)
+	case 2: // Down
+		return other.Top - current.Bottom, min(current.Right, other.Right) - max(current.Left, other.Left)
+	}
+	return -1, 0
+}
+
+// nearestMonitor returns the monitor with the closest center
+func nearestMonitor(monitors []Monitor, currentMonitor *Monitor) *Monitor {
 	var targetMonitor *Monitor
 	minDistance := math.MaxFloat64
 
-	for _, monitor := range candidates {
-		distance := math.Hypot(
-			monitor.Center.X-currentMonitor.Center.X,
-			monitor.Center.Y-currentMonitor.Center.Y,
-		)
+	for _, monitor := range monitors {
+		distance := monitorDistance(&monitor, currentMonitor)
 		if distance < minDistance {
 			minDistance = distance
 			targetMonitor = &monitor
@@ -191,3 +405,26 @@ func findTargetMonitor(monitors []Monitor, currentMonitor *Monitor, direction in
 
 	return targetMonitor
 }
+
+// farthestMonitor returns the monitor with the most distant center
+func farthestMonitor(monitors []Monitor, currentMonitor *Monitor) *Monitor {
+	var targetMonitor *Monitor
+	maxDistance := -1.0
+
+	for _, monitor := range monitors {
+		distance := monitorDistance(&monitor, currentMonitor)
+		if distance > maxDistance {
+			maxDistance = distance
+			targetMonitor = &monitor
+		}
+	}
+
+	return targetMonitor
+}
+
+func monitorDistance(a, b *Monitor) float64 {
+	return math.Hypot(
+		a.Center.X-b.Center.X,
+		a.Center.Y-b.Center.Y,
+	)
+}
@@ -0,0 +1,265 @@
+package window
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testMonitors creates monitors with handles 1..n from the given bounds
+func testMonitors(bounds ...RECT) []Monitor {
+	var monitors []Monitor
+	for i, b := range bounds {
+		mi := MONITORINFO{RCMonitor: b, RCWork: b}
+		monitors = append(monitors, Monitor{
+			HMonitor: Handle(i + 1),
+			Info:     mi,
+			Center:   calculateMonitorCenter(mi),
+		})
+	}
+	return monitors
+}
+
+func TestFindTargetMonitorWithFallback(t *testing.T) {
+	// Three monitors in a row and a fourth one above the middle monitor
+	monitors := testMonitors(
+		rect(0, 0, 1920, 1080),
+		rect(1920, 0, 3840, 1080),
+		rect(3840, 0, 5760, 1080),
+		rect(1920, -1080, 3840, 0),
+	)
+
+	tests := []struct {
+		name      string
+		fallback  string
+		current   int
+		direction int
+		want      Handle
+	}{
+		{"monitor in the direction", FallbackNone, 0, 1, 2},
+		{"none on the rightmost monitor", FallbackNone, 2, 1, 0},
+		{"wrap from the rightmost monitor", FallbackWrap, 2, 1, 1},
+		{"wrap from the leftmost monitor", FallbackWrap, 0, -1, 3},
+		{"wrap from the top monitor", FallbackWrap, 3, -2, 2},
+		{"wrap ignores found monitors", FallbackWrap, 1, 1, 3},
+		{"nearest from the rightmost monitor", FallbackNearest, 2, 1, 2},
+		{"nearest from the leftmost monitor down", FallbackNearest, 0, 2, 2},
+		{"invalid direction", FallbackWrap, 0, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := MonitorFallback
+			MonitorFallback = tt.fallback
+			t.Cleanup(func() {
+				MonitorFallback = previous
+			})
+
+			got := findTargetMonitorWithFallback(monitors, &monitors[tt.current], tt.direction)
+			var gotHandle Handle
+			if got != nil {
+				gotHandle = got.HMonitor
+			}
+			if gotHandle != tt.want {
+				t.Errorf("findTargetMonitorWithFallback() = monitor %v, want monitor %v", gotHandle, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveActiveWindowWrap(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := MonitorFallback
+	MonitorFallback = FallbackWrap
+	t.Cleanup(func() {
+		MonitorFallback = previous
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	fake.AddMonitor(rect(3840, 0, 5760, 1080), rect(3840, 0, 5760, 1080))
+	hwnd := fake.AddWindow(rect(3940, 100, 4900, 640))
+
+	MoveActiveWindow(1)
+
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+}
+
+func TestFindTargetMonitorAlgorithms(t *testing.T) {
+	tests := []struct {
+		name      string
+		monitors  []Monitor
+		current   int
+		direction int
+		edge      Handle
+		center    Handle
+	}{
+		{
+			name:      "narrow laptop below the left part of a wide monitor",
+			monitors:  testMonitors(rect(0, 0, 5120, 1440), rect(0, 1440, 1920, 2520)),
+			current:   1,
+			direction: -2,
+			edge:      1,
+			center:    0,
+		},
+		{
+			name:      "nothing right of a laptop below a wide monitor",
+			monitors:  testMonitors(rect(0, 0, 5120, 1440), rect(0, 1440, 1920, 2520)),
+			current:   1,
+			direction: 1,
+			edge:      0,
+			center:    1,
+		},
+		{
+			name:      "laptop below-left only touching the corner",
+			monitors:  testMonitors(rect(0, 0, 3840, 2160), rect(-1920, 2160, 0, 3240)),
+			current:   1,
+			direction: 1,
+			edge:      1,
+			center:    1,
+		},
+		{
+			name:      "touching monitor wins over a closer center",
+			monitors:  testMonitors(rect(0, 0, 1920, 1080), rect(1920, 600, 3840, 1680), rect(1920, -1500, 2720, 20)),
+			current:   0,
+			direction: 1,
+			edge:      2,
+			center:    3,
+		},
+		{
+			name:      "larger shared edge wins",
+			monitors:  testMonitors(rect(0, 0, 1920, 1080), rect(1920, -800, 3840, 280), rect(1920, 280, 3840, 1360)),
+			current:   0,
+			direction: 1,
+			edge:      3,
+			center:    3,
+		},
+		{
+			name:      "stacked monitors",
+			monitors:  testMonitors(rect(0, 0, 1920, 1080), rect(0, -1080, 1920, 0), rect(0, -2160, 1920, -1080)),
+			current:   0,
+			direction: -2,
+			edge:      2,
+			center:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, algorithm := range []string{NeighborEdge, NeighborCenter} {
+			t.Run(tt.name+" "+algorithm, func(t *testing.T) {
+				previous := NeighborAlgorithm
+				NeighborAlgorithm = algorithm
+				t.Cleanup(func() {
+					NeighborAlgorithm = previous
+				})
+
+				want := tt.edge
+				if algorithm == NeighborCenter {
+					want = tt.center
+				}
+				got := findTargetMonitor(tt.monitors, &tt.monitors[tt.current], tt.direction)
+				var gotHandle Handle
+				if got != nil {
+					gotHandle = got.HMonitor
+				}
+				if gotHandle != want {
+					t.Errorf("findTargetMonitor() = monitor %v, want monitor %v", gotHandle, want)
+				}
+			})
+		}
+	}
+}
+
+func TestResolveMonitor(t *testing.T) {
+	monitors := testMonitors(
+		rect(1920, 0, 3840, 1080),
+		rect(-1920, 0, 0, 1080),
+		rect(0, 0, 1920, 1080),
+		rect(0, -1080, 1920, 0),
+	)
+	for i := range monitors {
+		monitors[i].Name = fmt.Sprintf(`\\.\DISPLAY%d`, i+1)
+	}
+	previous := MonitorAliases
+	MonitorAliases = map[string]string{"laptop": `\\.\DISPLAY2`}
+	t.Cleanup(func() {
+		MonitorAliases = previous
+	})
+
+	tests := []struct {
+		target string
+		want   Handle
+	}{
+		{"1", 2},
+		{"2", 4},
+		{"3", 3},
+		{"4", 1},
+		{"5", 0},
+		{"0", 0},
+		{"laptop", 2},
+		{`\\.\display3`, 3},
+		{"unknown", 0},
+	}
+
+	for _, tt := range tests {
+		got := resolveMonitor(monitors, tt.target)
+		var gotHandle Handle
+		if got != nil {
+			gotHandle = got.HMonitor
+		}
+		if gotHandle != tt.want {
+			t.Errorf("resolveMonitor(%q) = monitor %v, want monitor %v", tt.target, gotHandle, tt.want)
+		}
+	}
+}
+
+func TestMoveActiveWindowToMonitor(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(3840, 0, 5760, 1080), rect(3840, 0, 5760, 1080))
+	hwnd := fake.AddWindow(rect(2020, 100, 2980, 640))
+
+	MoveActiveWindowToMonitor("3")
+	assertRect(t, fake, hwnd, rect(3940, 100, 4900, 640))
+
+	MoveActiveWindowToMonitor("1")
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+
+	// Unknown monitors are ignored
+	MoveActiveWindowToMonitor("4")
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+}
+
+func TestMonitorSetting(t *testing.T) {
+	// Two monitors with the same resolution
+	monitors := testMonitors(rect(0, 0, 1920, 1080), rect(1920, 0, 3840, 1080))
+	monitors[0].Name = `\\.\DISPLAY1`
+	monitors[1].Name = `\\.\DISPLAY2`
+	previous := MonitorAliases
+	MonitorAliases = map[string]string{"main": `\\.\DISPLAY1`, "center": `\\.\DISPLAY1`}
+	t.Cleanup(func() {
+		MonitorAliases = previous
+	})
+
+	tests := []struct {
+		name     string
+		monitor  int
+		settings map[string]int
+		want     int
+		found    bool
+	}{
+		{"device name wins", 0, map[string]int{"1": 1, "main": 2, `\\.\DISPLAY1`: 3}, 3, true},
+		{"alias wins over index", 0, map[string]int{"1": 1, "main": 2}, 2, true},
+		{"aliases in alphabetical order", 0, map[string]int{"main": 2, "center": 4}, 4, true},
+		{"index", 1, map[string]int{"1": 1, "2": 5}, 5, true},
+		{"not configured", 1, map[string]int{"1": 1, "main": 2}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := monitorSetting(monitors, &monitors[tt.monitor], tt.settings)
+			if got != tt.want || found != tt.found {
+				t.Errorf("monitorSetting() = %d, %v, want %d, %v", got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package window
+
+import (
+	"log"
+	"telewindow/rules"
+)
+
+// Rules places newly opened windows, nil applies no rules
+var Rules *rules.Engine
+
+// ApplyRules places the window by the first rule matching it and returns true if a rule was applied
+func ApplyRules(hwnd Handle) bool {
+	if Rules.Len() == 0 {
+		return false
+	}
+	info, err := backend.WindowInfo(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window info:", err)
+		return false
+	}
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return false
+	}
+	rule, ok := Rules.Evaluate(rules.Window{Exe: info.Exe, Class: info.Class, Title: info.Title}, len(monitors))
+	if !ok {
+		return false
+	}
+	log.Printf("DEBUG: Applying rule %q to %s %q\n", rule.Name, info.Exe, info.Title)
+	if excluded(hwnd, monitors, rule.Action.Split != rules.SplitNone || rule.Action.Cell != nil || rule.Action.Maximize) {
+		return false
+	}
+
+	rect, frame, err := getVisibleWindowRect(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return false
+	}
+	currentMonitor := findCurrentMonitor(monitors, rect)
+	targetMonitor := currentMonitor
+	if rule.Action.Monitor != "" {
+		if m := resolveMonitor(monitors, rule.Action.Monitor); m != nil {
+			targetMonitor = m
+		} else {
+			log.Printf("DEBUG: Monitor %q not found, keeping the current monitor.\n", rule.Action.Monitor)
+		}
+	}
+	if targetMonitor == nil {
+		targetMonitor = activeOrPrimaryMonitor(monitors)
+	}
+	if targetMonitor == nil {
+		log.Println("DEBUG: Target monitor not found.")
+		return false
+	}
+
+	newRect, snap := rule.Action.Rect(targetMonitor.placement().Area(UseWorkArea), gridForMonitor(targetMonitor))
+	if snap {
+		log.Printf("DEBUG: New window position: %+v\n", newRect)
+		recordWindowState(hwnd)
+		if maximized, err := IsActiveWindowMaximized(&hwnd); err == nil && maximized {
+			RestoreActiveWindow(&hwnd)
+		}
+		if err := setWindowRect(hwnd, RECT(frame.Window(newRect))); err != nil {
+			log.Println("DEBUG: MoveWindow failed:", err)
+			return false
+		}
+	} else {
+		if rule.Action.Cell != nil {
+			log.Printf("DEBUG: Cell %+v is outside the grid, keeping the window size.\n", *rule.Action.Cell)
+		}
+		switch {
+		case currentMonitor == nil:
+			centerWindow(hwnd, targetMonitor)
+		case currentMonitor.HMonitor != targetMonitor.HMonitor:
+			moveWindow(hwnd, monitors, currentMonitor, targetMonitor, placementOptions())
+		}
+	}
+	if rule.Action.Maximize {
+		MaximizeActiveWindow(&hwnd)
+	}
+	return true
+}
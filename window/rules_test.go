@@ -0,0 +1,52 @@
+package window
+
+import (
+	"telewindow/rules"
+	"testing"
+)
+
+func TestApplyRules(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	engine, err := rules.New([]rules.Rule{
+		{Name: "notes", Match: rules.Match{Exe: "notepad.exe"}, Action: rules.Action{Monitor: "2", Split: rules.SplitRight}},
+		{Name: "editor", Match: rules.Match{Title: "Visual Studio Code$"}, Action: rules.Action{Monitor: "2", Maximize: true}},
+		{Name: "terminal", Match: rules.Match{Class: "CASCADIA_HOSTING_WINDOW_CLASS"}, Action: rules.Action{Monitor: "2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := Rules
+	Rules = engine
+	t.Cleanup(func() {
+		Rules = previous
+	})
+
+	tests := []struct {
+		name    string
+		info    WindowInfo
+		applied bool
+		want    RECT
+		showCmd uint32
+	}{
+		{"split on another monitor", WindowInfo{Exe: "notepad.exe", Class: "Notepad"}, true, rect(2880, 0, 3840, 1040), SW_SHOWNORMAL},
+		{"maximized on another monitor", WindowInfo{Exe: "Code.exe", Title: "main.go - Visual Studio Code"}, true, rect(1920, 0, 3840, 1040), SW_SHOWMAXIMIZED},
+		{"moved to another monitor", WindowInfo{Exe: "WindowsTerminal.exe", Class: "CASCADIA_HOSTING_WINDOW_CLASS"}, true, rect(2020, 100, 2980, 620), SW_SHOWNORMAL},
+		{"no matching rule", WindowInfo{Exe: "firefox.exe", Title: "News"}, false, rect(100, 100, 1060, 620), SW_SHOWNORMAL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hwnd := fake.AddWindow(rect(100, 100, 1060, 620))
+			fake.SetWindowInfo(hwnd, tt.info)
+			if got := ApplyRules(hwnd); got != tt.applied {
+				t.Errorf("ApplyRules() = %v, want %v", got, tt.applied)
+			}
+			assertRect(t, fake, hwnd, tt.want)
+			if w, _ := fake.Window(hwnd); w.ShowCmd != tt.showCmd {
+				t.Errorf("show state = %d, want %d", w.ShowCmd, tt.showCmd)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package window
+
+import (
+	"log"
+	"sync"
+	"telewindow/placement"
+)
+
+// snapState remembers a window from before its first snap
+type snapState struct {
+	// restore is the geometry and show state the window returns to when it is unsnapped
+	restore windowState
+	// snapped is the window rect set by the last snap, a window moved away from it is snapped again from scratch
+	snapped RECT
+}
+
+var (
+	snapMu     sync.Mutex
+	snapStates = map[Handle]*snapState{}
+)
+
+// rememberSnapState saves the state of the window before a snap, unless the window is still where the last snap put it
+func rememberSnapState(hwnd Handle, current RECT, restore windowState) {
+	snapMu.Lock()
+	defer snapMu.Unlock()
+
+	pruneSnapStates()
+	if s, exists := snapStates[hwnd]; exists && restore.ShowCmd != SW_SHOWMAXIMIZED &&
+		placement.Snapped(placement.Rect(current), placement.Rect(s.snapped)) {
+		return
+	}
+	log.Printf("DEBUG: Remembering window state before snap: %+v\n", restore)
+	snapStates[hwnd] = &snapState{restore: restore}
+}
+
+// setSnappedRect saves the rect the window was snapped to
+func setSnappedRect(hwnd Handle, rect RECT) {
+	snapMu.Lock()
+	defer snapMu.Unlock()
+
+	if s, exists := snapStates[hwnd]; exists {
+		s.snapped = rect
+	}
+}
+
+// isSnapped reports if the window is still where the last snap put it
+func isSnapped(hwnd Handle) bool {
+	rect, err := backend.WindowRect(hwnd)
+	if err != nil {
+		return false
+	}
+
+	snapMu.Lock()
+	defer snapMu.Unlock()
+
+	s, exists := snapStates[hwnd]
+	return exists && placement.Snapped(placement.Rect(*rect), placement.Rect(s.snapped))
+}
+
+// pruneSnapStates drops the snap state of windows that no longer exist
+func pruneSnapStates() {
+	for hwnd := range snapStates {
+		if !backend.IsWindow(hwnd) {
+			delete(snapStates, hwnd)
+		}
+	}
+}
+
+// UnsnapActiveWindow returns the active window to its geometry and show state from before it was first snapped
+func UnsnapActiveWindow() {
+	log.Println("DEBUG: Entering UnsnapActiveWindow()")
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	if excluded(activeWindow, nil, true) {
+		return
+	}
+
+	snapMu.Lock()
+	pruneSnapStates()
+	s, exists := snapStates[activeWindow]
+	delete(snapStates, activeWindow)
+	snapMu.Unlock()
+	if !exists {
+		log.Println("DEBUG: Window is not snapped.")
+		return
+	}
+
+	recordWindowState(activeWindow)
+
+	maximized, err := IsActiveWindowMaximized(&activeWindow)
+	if err != nil {
+		log.Println("DEBUG: Error checking if window is maximized:", err)
+		return
+	}
+	if maximized {
+		RestoreActiveWindow(&activeWindow)
+	}
+
+	log.Printf("DEBUG: Restoring window rect: %+v\n", s.restore.Rect)
+	err = setWindowRect(activeWindow, s.restore.Rect)
+	if err != nil {
+		log.Println("DEBUG: MoveWindow failed:", err)
+		return
+	}
+
+	if s.restore.ShowCmd == SW_SHOWMAXIMIZED {
+		log.Println("DEBUG: Window was maximized before the snap, maximizing window again.")
+		MaximizeActiveWindow(&activeWindow)
+	}
+	log.Println("DEBUG: Window unsnapped successfully.")
+}
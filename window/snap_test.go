@@ -0,0 +1,56 @@
+package window
+
+import "testing"
+
+func TestSplitActiveWindowToggle(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(0, 0, 960, 1040))
+
+	// The geometry from before the first snap is kept across snaps
+	SplitActiveWindow(-2)
+	assertRect(t, fake, hwnd, rect(0, 0, 960, 520))
+	SplitActiveWindow(2)
+	assertRect(t, fake, hwnd, rect(0, 520, 960, 1040))
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(0, 0, 960, 1040))
+
+	// The same split again unsnaps the window
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+
+	// A window that was moved after its snap remembers the new geometry
+	SplitActiveWindow(1)
+	fake.SetWindowRect(hwnd, rect(300, 200, 900, 600))
+	SplitActiveWindow(1)
+	assertRect(t, fake, hwnd, rect(960, 0, 1920, 1040))
+	UnsnapActiveWindow()
+	assertRect(t, fake, hwnd, rect(300, 200, 900, 600))
+
+	// Unsnapping a window that is not snapped does nothing
+	UnsnapActiveWindow()
+	assertRect(t, fake, hwnd, rect(300, 200, 900, 600))
+}
+
+func TestUnsnapMaximizedWindow(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+	MaximizeActiveWindow(nil)
+
+	SplitActiveWindow(1)
+	assertRect(t, fake, hwnd, rect(960, 0, 1920, 1040))
+
+	UnsnapActiveWindow()
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 1040))
+	if w, _ := fake.Window(hwnd); w.ShowCmd != SW_SHOWMAXIMIZED {
+		t.Errorf("show state = %d, want maximized", w.ShowCmd)
+	}
+
+	// Restoring the window afterwards returns it to its floating size
+	RestoreActiveWindow(nil)
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+}
@@ -0,0 +1,319 @@
+package window
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"telewindow/placement"
+)
+
+// SnapshotFile is the JSON file the saved window layouts are stored in
+var SnapshotFile = "layouts.json"
+
+// SavedWindow is a window in a saved layout
+type SavedWindow struct {
+	Exe   string `json:"exe"`
+	Class string `json:"class"`
+	Title string `json:"title"`
+	// Monitor is the device name of the monitor the window was on
+	Monitor string `json:"monitor"`
+	// MonitorBounds and MonitorWorkArea are the geometry of the monitor when the layout was saved,
+	// the window is placed relative to them on restore
+	MonitorBounds   RECT `json:"monitorBounds"`
+	MonitorWorkArea RECT `json:"monitorWorkArea"`
+	Rect            RECT `json:"rect"`
+	Maximized       bool `json:"maximized"`
+}
+
+// loadSnapshots reads all saved layouts by name, a missing file has no layouts
+func loadSnapshots() (map[string][]SavedWindow, error) {
+	snapshots := map[string][]SavedWindow{}
+	data, err := os.ReadFile(SnapshotFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// SnapshotNames returns the names of the saved layouts in alphabetical order, without the layouts saved per monitor topology
+func SnapshotNames() ([]string, error) {
+	snapshots, err := loadSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range snapshots {
+		if !strings.HasPrefix(name, topologyPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SaveSnapshot records the executable, class, title, monitor and geometry of every top-level window under the name
+func SaveSnapshot(name string) error {
+	monitors, err := GetMonitors()
+	if err != nil {
+		return err
+	}
+	saved, err := captureSnapshot(monitors)
+	if err != nil {
+		return err
+	}
+	return writeSnapshot(name, saved)
+}
+
+// captureSnapshot returns the saved state of every top-level window on the monitors
+func captureSnapshot(monitors []Monitor) ([]SavedWindow, error) {
+	handles, err := backend.Windows()
+	if err != nil {
+		return nil, err
+	}
+
+	var saved []SavedWindow
+	for _, hwnd := range handles {
+		info, err := backend.WindowInfo(hwnd)
+		if err != nil {
+			log.Println("DEBUG: Error getting window info:", err)
+			continue
+		}
+		rect, _, err := visibleRect(hwnd)
+		if err != nil {
+			log.Println("DEBUG: Error getting window rect:", err)
+			continue
+		}
+		monitor := findCurrentMonitor(monitors, rect)
+		if monitor == nil {
+			continue
+		}
+		showCmd, err := backend.ShowState(hwnd)
+		if err != nil {
+			log.Println("DEBUG: Error getting window show state:", err)
+			continue
+		}
+		saved = append(saved, SavedWindow{
+			Exe:             info.Exe,
+			Class:           info.Class,
+			Title:           info.Title,
+			Monitor:         monitor.Name,
+			MonitorBounds:   monitor.Info.RCMonitor,
+			MonitorWorkArea: monitor.Info.RCWork,
+			Rect:            *rect,
+			Maximized:       showCmd == SW_SHOWMAXIMIZED,
+		})
+	}
+	return saved, nil
+}
+
+// visibleRect returns the visible frame of the window and its invisible resize borders like getVisibleWindowRect.
+// It does not log, layouts are captured and restored for every window while layouts are restored automatically.
+func visibleRect(hwnd Handle) (*RECT, placement.Frame, error) {
+	rect, err := backend.WindowRect(hwnd)
+	if err != nil {
+		return nil, placement.Frame{}, err
+	}
+	visible, err := backend.VisibleRect(hwnd)
+	if err != nil {
+		return rect, placement.Frame{}, nil
+	}
+	frame := placement.FrameOf(placement.Rect(*rect), placement.Rect(*visible))
+	r := RECT(frame.Visible(placement.Rect(*rect)))
+	return &r, frame, nil
+}
+
+// writeSnapshot stores the saved windows under the name, replacing an existing layout with that name
+func writeSnapshot(name string, saved []SavedWindow) error {
+	snapshots, err := loadSnapshots()
+	if err != nil {
+		return err
+	}
+	snapshots[name] = saved
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(SnapshotFile, data, 0o644)
+}
+
+// RestoreSnapshot moves the top-level windows back to the geometry saved under the name.
+// Windows are matched by executable, class and title, and placed relative to their saved monitor
+// or the primary monitor if it is no longer connected.
+func RestoreSnapshot(name string) error {
+	snapshots, err := loadSnapshots()
+	if err != nil {
+		return err
+	}
+	saved, exists := snapshots[name]
+	if !exists {
+		return fmt.Errorf("layout %q not found", name)
+	}
+
+	monitors, err := GetMonitors()
+	if err != nil {
+		return err
+	}
+	return applySnapshot(saved, monitors)
+}
+
+// applySnapshot matches the top-level windows to the saved windows and restores their geometry
+func applySnapshot(saved []SavedWindow, monitors []Monitor) error {
+	handles, err := backend.Windows()
+	if err != nil {
+		return err
+	}
+	infos := make([]WindowInfo, len(handles))
+	for i, hwnd := range handles {
+		if infos[i], err = backend.WindowInfo(hwnd); err != nil {
+			log.Println("DEBUG: Error getting window info:", err)
+		}
+	}
+
+	opts := placementOptions()
+	opts.Mode = placement.Percentage
+	for i, match := range matchSavedWindows(saved, infos) {
+		if match >= 0 {
+			restoreSavedWindow(handles[match], saved[i], monitors, opts)
+		}
+	}
+	RetileMonitors()
+	return nil
+}
+
+// savedMonitor returns the monitor the window was saved on: the monitor at the same position,
+// the monitor with the same device name or the primary monitor
+func savedMonitor(monitors []Monitor, saved SavedWindow) *Monitor {
+	for i := range monitors {
+		if monitors[i].Info.RCMonitor == saved.MonitorBounds {
+			return &monitors[i]
+		}
+	}
+	if m := resolveMonitor(monitors, saved.Monitor); m != nil {
+		return m
+	}
+	return activeOrPrimaryMonitor(monitors)
+}
+
+// restoreSavedWindow places the window relative to the monitor it was saved on
+func restoreSavedWindow(hwnd Handle, saved SavedWindow, monitors []Monitor, opts placement.Options) {
+	target := savedMonitor(monitors, saved)
+	if target == nil || excluded(hwnd, monitors, true) {
+		return
+	}
+	source := placement.Monitor{Bounds: placement.Rect(saved.MonitorBounds), WorkArea: placement.Rect(saved.MonitorWorkArea)}
+	newRect := placement.Move(placement.Rect(saved.Rect), source, target.placement(), opts)
+
+	// The backend is used directly, the window helpers log every step and layouts are restored for every window
+	recordWindowState(hwnd)
+	showCmd, err := backend.ShowState(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window show state:", err)
+		return
+	}
+	if showCmd == SW_SHOWMAXIMIZED {
+		if err := backend.ShowWindow(hwnd, SW_RESTORE); err != nil {
+			log.Println("DEBUG: ShowWindow failed:", err)
+			return
+		}
+	}
+	rect, frame, err := visibleRect(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return
+	}
+	if saved.Maximized {
+		// Place the restored size on the target monitor so the window is maximized there
+		newRect = placement.Center(placement.Rect(*rect), target.placement().Area(UseWorkArea))
+	}
+	if err := backend.SetWindowRect(hwnd, RECT(frame.Window(newRect))); err != nil {
+		log.Println("DEBUG: MoveWindow failed:", err)
+		return
+	}
+	if saved.Maximized {
+		if err := backend.ShowWindow(hwnd, SW_MAXIMIZE); err != nil {
+			log.Println("DEBUG: ShowWindow failed:", err)
+		}
+	}
+}
+
+// matchScore rates how well the window matches the saved window, 0 is no match.
+// The executable has to match, a matching class and an exact or similar title raise the score.
+// The executable is unknown for windows of elevated or protected processes, those have to match by class.
+func matchScore(saved SavedWindow, info WindowInfo) int {
+	if !strings.EqualFold(saved.Exe, info.Exe) {
+		return 0
+	}
+	if saved.Exe == "" && (saved.Class == "" || saved.Class != info.Class) {
+		return 0
+	}
+	score := 1
+	if saved.Class == info.Class {
+		score += 2
+	}
+	switch {
+	case saved.Title == info.Title:
+		score += 4
+	case saved.Title != "" && info.Title != "" &&
+		(strings.Contains(info.Title, saved.Title) || strings.Contains(saved.Title, info.Title)):
+		score += 2
+	case sharedWords(saved.Title, info.Title)*2 >= len(strings.Fields(saved.Title)) && saved.Title != "":
+		score++
+	}
+	return score
+}
+
+// sharedWords counts the words of a that also appear in b, ignoring case
+func sharedWords(a, b string) int {
+	words := map[string]bool{}
+	for _, w := range strings.Fields(strings.ToLower(b)) {
+		words[w] = true
+	}
+	shared := 0
+	for _, w := range strings.Fields(strings.ToLower(a)) {
+		if words[w] {
+			shared++
+		}
+	}
+	return shared
+}
+
+// matchSavedWindows returns the index of the window matching each saved window, -1 if none matches.
+// The best scoring pairs are matched first and every window is matched at most once.
+func matchSavedWindows(saved []SavedWindow, infos []WindowInfo) []int {
+	type pair struct{ saved, window, score int }
+	var pairs []pair
+	for i := range saved {
+		for j := range infos {
+			if score := matchScore(saved[i], infos[j]); score > 0 {
+				pairs = append(pairs, pair{i, j, score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return pairs[a].score > pairs[b].score
+	})
+
+	matches := make([]int, len(saved))
+	for i := range matches {
+		matches[i] = -1
+	}
+	used := make([]bool, len(infos))
+	for _, p := range pairs {
+		if matches[p.saved] < 0 && !used[p.window] {
+			matches[p.saved] = p.window
+			used[p.window] = true
+		}
+	}
+	return matches
+}
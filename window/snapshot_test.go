@@ -0,0 +1,88 @@
+package window
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchSavedWindows(t *testing.T) {
+	saved := []SavedWindow{
+		{Exe: "Code.exe", Class: "Chrome_WidgetWin_1", Title: "main.go - telewindow - Visual Studio Code"},
+		{Exe: "Code.exe", Class: "Chrome_WidgetWin_1", Title: "README.md - notes - Visual Studio Code"},
+		{Exe: "notepad.exe", Class: "Notepad", Title: "todo.txt - Notepad"},
+		{Exe: "explorer.exe", Class: "CabinetWClass", Title: "Downloads"},
+	}
+	infos := []WindowInfo{
+		{Exe: "code.exe", Class: "Chrome_WidgetWin_1", Title: "notes.md - notes - Visual Studio Code"},
+		{Exe: "Code.exe", Class: "Chrome_WidgetWin_1", Title: "main.go - telewindow - Visual Studio Code"},
+		{Exe: "notepad.exe", Class: "Notepad", Title: "Untitled - Notepad"},
+		{Exe: "chrome.exe", Class: "Chrome_WidgetWin_1", Title: "Downloads"},
+	}
+
+	// The exact title wins, the other Code window takes the similar one and a different exe never matches
+	want := []int{1, 0, 2, -1}
+	if got := matchSavedWindows(saved, infos); !reflect.DeepEqual(got, want) {
+		t.Errorf("matchSavedWindows() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchSavedWindowsUnknownExe(t *testing.T) {
+	// The executables of elevated windows cannot be queried
+	saved := []SavedWindow{
+		{Class: "ConsoleWindowClass", Title: "Administrator: Command Prompt"},
+		{Class: "TaskManagerWindow", Title: "Task Manager"},
+	}
+	infos := []WindowInfo{
+		{Class: "RegEdit_RegEdit", Title: "Registry Editor"},
+		{Class: "ConsoleWindowClass", Title: "Administrator: Windows PowerShell"},
+	}
+
+	// Without an executable only a window of the same class matches
+	want := []int{1, -1}
+	if got := matchSavedWindows(saved, infos); !reflect.DeepEqual(got, want) {
+		t.Errorf("matchSavedWindows() = %v, want %v", got, want)
+	}
+}
+
+func TestSaveAndRestoreSnapshot(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := SnapshotFile
+	SnapshotFile = filepath.Join(t.TempDir(), "layouts.json")
+	t.Cleanup(func() {
+		SnapshotFile = previous
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	editor := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowInfo(editor, WindowInfo{Exe: "notepad.exe", Class: "Notepad", Title: "todo.txt - Notepad"})
+	browser := fake.AddWindow(rect(2020, 100, 2980, 640))
+	fake.SetWindowInfo(browser, WindowInfo{Exe: "firefox.exe", Class: "MozillaWindowClass", Title: "News"})
+	MaximizeActiveWindow(nil)
+
+	if err := SaveSnapshot("work"); err != nil {
+		t.Fatal(err)
+	}
+	if names, err := SnapshotNames(); err != nil || !reflect.DeepEqual(names, []string{"work"}) {
+		t.Fatalf("SnapshotNames() = %v, %v, want [work]", names, err)
+	}
+
+	// Mess up the windows, the browser title changed in the meantime
+	fake.SetWindowInfo(browser, WindowInfo{Exe: "firefox.exe", Class: "MozillaWindowClass", Title: "Weather"})
+	RestoreActiveWindow(nil)
+	fake.SetWindowRect(browser, rect(200, 200, 600, 600))
+	fake.SetWindowRect(editor, rect(3000, 500, 3500, 900))
+
+	if err := RestoreSnapshot("work"); err != nil {
+		t.Fatal(err)
+	}
+	assertRect(t, fake, editor, rect(100, 100, 1060, 640))
+	assertRect(t, fake, browser, rect(1920, 0, 3840, 1080))
+	if w, _ := fake.Window(browser); w.ShowCmd != SW_SHOWMAXIMIZED {
+		t.Errorf("show state = %d, want maximized", w.ShowCmd)
+	}
+
+	if err := RestoreSnapshot("home"); err == nil {
+		t.Error("restoring an unknown layout should fail")
+	}
+}
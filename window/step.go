@@ -0,0 +1,40 @@
+package window
+
+import (
+	"log"
+	"telewindow/placement"
+)
+
+// NudgeStep is the distance windows are nudged and resized by
+var NudgeStep = placement.Step{Pixels: 50}
+
+// CenterActiveWindow centers the active window on its current monitor
+func CenterActiveWindow() {
+	log.Println("DEBUG: Entering CenterActiveWindow()")
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		return placement.Center(window, area), true
+	})
+}
+
+// NudgeActiveWindow moves the active window by NudgeStep in the direction
+func NudgeActiveWindow(direction int) {
+	log.Println("DEBUG: Entering NudgeActiveWindow() with direction:", direction)
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		step := NudgeStep.Size(area, direction)
+		log.Println("DEBUG: Nudge step:", step)
+		return placement.Nudge(window, area, direction, step), true
+	})
+}
+
+// ResizeActiveWindowEdge moves the edge of the active window on the side of the direction outwards (grow) or inwards by NudgeStep
+func ResizeActiveWindowEdge(direction int, grow bool) {
+	log.Println("DEBUG: Entering ResizeActiveWindowEdge() with direction:", direction, "grow:", grow)
+	placeActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		step := NudgeStep.Size(area, direction)
+		if !grow {
+			step = -step
+		}
+		log.Println("DEBUG: Resize step:", step)
+		return placement.ResizeEdge(window, area, direction, step), true
+	})
+}
@@ -0,0 +1,40 @@
+package window
+
+import (
+	"telewindow/placement"
+	"testing"
+)
+
+func TestStepActions(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := NudgeStep
+	NudgeStep = placement.Step{Percent: 5}
+	t.Cleanup(func() {
+		NudgeStep = previous
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	hwnd := fake.AddWindow(rect(2000, 100, 2960, 620))
+
+	CenterActiveWindow()
+	assertRect(t, fake, hwnd, rect(2400, 260, 3360, 780))
+
+	NudgeActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(2304, 260, 3264, 780))
+
+	ResizeActiveWindowEdge(2, true)
+	assertRect(t, fake, hwnd, rect(2304, 260, 3264, 832))
+
+	ResizeActiveWindowEdge(1, false)
+	assertRect(t, fake, hwnd, rect(2304, 260, 3168, 832))
+
+	// Nudging stops at the edge of the current monitor
+	for i := 0; i < 10; i++ {
+		NudgeActiveWindow(2)
+	}
+	assertRect(t, fake, hwnd, rect(2304, 468, 3168, 1040))
+
+	// Centering, nudging and resizing are no snaps, there is nothing to unsnap
+	UnsnapActiveWindow()
+	assertRect(t, fake, hwnd, rect(2304, 468, 3168, 1040))
+}
@@ -0,0 +1,85 @@
+package window
+
+import "log"
+
+// SwapActiveWindowWithMonitor swaps the active window with the top window on the monitor in the direction.
+// Both windows keep their relative size and position and their maximized state.
+func SwapActiveWindowWithMonitor(direction int) {
+	log.Printf("DEBUG: Entering SwapActiveWindowWithMonitor() with direction: %d\n", direction)
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	if excluded(activeWindow, nil, false) {
+		return
+	}
+
+	rect, _, err := getVisibleWindowRect(activeWindow)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return
+	}
+
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+
+	currentMonitor := findCurrentMonitor(monitors, rect)
+	if currentMonitor == nil {
+		log.Println("DEBUG: Current monitor not found.")
+		return
+	}
+
+	targetMonitor := findTargetMonitorWithFallback(monitors, currentMonitor, direction)
+	if targetMonitor == nil {
+		log.Println("DEBUG: No monitor found in the desired direction.")
+		return
+	}
+
+	otherWindow, found := topWindowOnMonitor(monitors, targetMonitor, activeWindow)
+
+	if found {
+		log.Printf("DEBUG: Swapping with window %v\n", otherWindow)
+		if !moveWindow(otherWindow, monitors, targetMonitor, currentMonitor, placementOptions()) {
+			return
+		}
+	} else {
+		log.Println("DEBUG: No window on the target monitor, moving the active window only.")
+	}
+	if !moveWindow(activeWindow, monitors, currentMonitor, targetMonitor, placementOptions()) {
+		return
+	}
+	// Maximizing the other window again activates it, keep the focus on the window the user acted on
+	if found {
+		if err := backend.FocusWindow(activeWindow); err != nil {
+			log.Println("DEBUG: Error focusing window:", err)
+		}
+	}
+	log.Println("DEBUG: Windows swapped successfully.")
+	RetileMonitors()
+}
+
+// topWindowOnMonitor returns the topmost window on the monitor, ignoring the given and excluded windows
+func topWindowOnMonitor(monitors []Monitor, monitor *Monitor, ignore Handle) (Handle, bool) {
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return 0, false
+	}
+	for _, hwnd := range handles {
+		if hwnd == ignore || excluded(hwnd, monitors, false) {
+			continue
+		}
+		rect, _, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			continue
+		}
+		if m := findCurrentMonitor(monitors, rect); m != nil && m.HMonitor == monitor.HMonitor {
+			return hwnd, true
+		}
+	}
+	return 0, false
+}
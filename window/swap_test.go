@@ -0,0 +1,43 @@
+package window
+
+import "testing"
+
+func TestSwapActiveWindowWithMonitor(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 5760, 2160), rect(1920, 0, 5760, 2080))
+	bottom := fake.AddWindow(rect(2020, 100, 2980, 640))
+	other := fake.AddWindow(rect(3840, 1040, 5760, 2080))
+	fake.AddWindow(rect(100, 100, 1060, 640))
+	MaximizeActiveWindow(nil)
+	active, _ := GetActiveWindow()
+
+	SwapActiveWindowWithMonitor(1)
+
+	// The active window is maximized on the second monitor, the top window there takes its place
+	assertRect(t, fake, active, rect(1920, 0, 5760, 2080))
+	if w, _ := fake.Window(active); w.ShowCmd != SW_SHOWMAXIMIZED {
+		t.Errorf("show state = %d, want maximized", w.ShowCmd)
+	}
+	assertRect(t, fake, other, rect(960, 520, 1920, 1040))
+	assertRect(t, fake, bottom, rect(2020, 100, 2980, 640))
+
+	// Maximizing the other window again does not take the focus from the active window
+	fake.SetActiveWindow(other)
+	MaximizeActiveWindow(nil)
+	fake.SetActiveWindow(active)
+	RestoreActiveWindow(nil)
+	SwapActiveWindowWithMonitor(-1)
+	if w, _ := fake.Window(other); w.ShowCmd != SW_SHOWMAXIMIZED {
+		t.Errorf("show state = %d, want maximized", w.ShowCmd)
+	}
+	if got, _ := GetActiveWindow(); got != active {
+		t.Errorf("active window = %v, want %v", got, active)
+	}
+
+	// Without a window on the target monitor the active window is only moved
+	fake.RemoveWindow(other)
+	fake.SetActiveWindow(bottom)
+	SwapActiveWindowWithMonitor(-1)
+	assertRect(t, fake, bottom, rect(50, 50, 530, 320))
+}
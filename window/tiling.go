@@ -0,0 +1,111 @@
+package window
+
+import (
+	"log"
+	"sync"
+	"telewindow/placement"
+)
+
+// Tiling enables the automatic layouts of the monitors
+var Tiling = false
+
+// DefaultLayout is the layout of monitors without a layout in Layouts
+var DefaultLayout = placement.Layout{}
+
+// Layouts holds the layout per monitor index, alias or device name
+var Layouts = map[string]placement.Layout{}
+
+var (
+	tilingMu sync.Mutex
+	// tilingOrder is the order windows are tiled in, windows are appended when they are first seen
+	tilingOrder []Handle
+)
+
+// layoutForMonitor returns the layout configured for the monitor
+func layoutForMonitor(monitors []Monitor, m *Monitor) placement.Layout {
+	if layout, ok := monitorSetting(monitors, m, Layouts); ok {
+		return layout
+	}
+	return DefaultLayout
+}
+
+// updateTilingOrder keeps the order of known windows, drops closed windows and appends new windows
+func updateTilingOrder(handles []Handle) []Handle {
+	tilingMu.Lock()
+	defer tilingMu.Unlock()
+
+	current := make(map[Handle]bool, len(handles))
+	for _, hwnd := range handles {
+		current[hwnd] = true
+	}
+	known := make(map[Handle]bool, len(tilingOrder))
+	order := tilingOrder[:0]
+	for _, hwnd := range tilingOrder {
+		if current[hwnd] {
+			order = append(order, hwnd)
+			known[hwnd] = true
+		}
+	}
+	// New windows are enumerated topmost first, the newest window ends up last
+	for i := len(handles) - 1; i >= 0; i-- {
+		if !known[handles[i]] {
+			order = append(order, handles[i])
+		}
+	}
+	tilingOrder = order
+	return append([]Handle(nil), order...)
+}
+
+// RetileMonitors arranges the windows of every monitor with a layout, maximized and excluded windows are left alone
+func RetileMonitors() {
+	if !Tiling {
+		return
+	}
+	log.Println("DEBUG: Entering RetileMonitors()")
+
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return
+	}
+
+	// Group the windows by monitor in tiling order
+	tiled := make(map[Handle][]Handle)
+	frames := make(map[Handle]placement.Frame)
+	for _, hwnd := range updateTilingOrder(handles) {
+		showCmd, err := backend.ShowState(hwnd)
+		if err != nil || showCmd == SW_SHOWMAXIMIZED || excluded(hwnd, monitors, true) {
+			continue
+		}
+		rect, frame, err := getVisibleWindowRect(hwnd)
+		if err != nil {
+			continue
+		}
+		if m := findCurrentMonitor(monitors, rect); m != nil {
+			tiled[m.HMonitor] = append(tiled[m.HMonitor], hwnd)
+			frames[hwnd] = frame
+		}
+	}
+
+	for i := range monitors {
+		m := &monitors[i]
+		layout := layoutForMonitor(monitors, m)
+		rects := layout.Tile(m.placement().Area(UseWorkArea), len(tiled[m.HMonitor]))
+		if rects == nil {
+			continue
+		}
+		log.Printf("DEBUG: Tiling %d windows on monitor %s with layout %q\n", len(rects), m.Name, layout.Kind)
+		for j, hwnd := range tiled[m.HMonitor] {
+			err := backend.SetWindowRect(hwnd, RECT(frames[hwnd].Window(rects[j])))
+			if err != nil {
+				log.Println("DEBUG: MoveWindow failed:", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package window
+
+import (
+	"reflect"
+	"telewindow/placement"
+	"testing"
+)
+
+// useTiling enables tiling with the layouts for the duration of the test
+func useTiling(t *testing.T, defaultLayout placement.Layout, layouts map[string]placement.Layout) {
+	t.Helper()
+	previousTiling, previousDefault, previousLayouts := Tiling, DefaultLayout, Layouts
+	Tiling, DefaultLayout, Layouts = true, defaultLayout, layouts
+	t.Cleanup(func() {
+		Tiling, DefaultLayout, Layouts = previousTiling, previousDefault, previousLayouts
+	})
+}
+
+func TestWindowWatcher(t *testing.T) {
+	fake := useFakeBackend(t)
+	first := fake.AddWindow(rect(0, 0, 100, 100))
+	watcher := NewWindowWatcher()
+
+	second := fake.AddWindow(rect(0, 0, 100, 100))
+	created, destroyed, changed := watcher.Poll()
+	if !reflect.DeepEqual(created, []Handle{second}) || destroyed != nil || !changed {
+		t.Errorf("Poll() = %v, %v, %v, want [%v], [], true", created, destroyed, changed, second)
+	}
+
+	fake.RemoveWindow(first)
+	created, destroyed, changed = watcher.Poll()
+	if created != nil || !reflect.DeepEqual(destroyed, []Handle{first}) || !changed {
+		t.Errorf("Poll() = %v, %v, %v, want [], [%v], true", created, destroyed, changed, first)
+	}
+
+	created, destroyed, changed = watcher.Poll()
+	if created != nil || destroyed != nil || changed {
+		t.Errorf("Poll() = %v, %v, %v, want [], [], false", created, destroyed, changed)
+	}
+}
+
+func TestWindowWatcherMinimizeRestore(t *testing.T) {
+	fake := useFakeBackend(t)
+	hwnd := fake.AddWindow(rect(0, 0, 100, 100))
+	watcher := NewWindowWatcher()
+
+	// Minimized windows are not enumerated but still exist
+	fake.ShowWindow(hwnd, SW_SHOWMINIMIZED)
+	created, destroyed, changed := watcher.Poll()
+	if created != nil || destroyed != nil || !changed {
+		t.Errorf("Poll() after minimize = %v, %v, %v, want [], [], true", created, destroyed, changed)
+	}
+
+	fake.ShowWindow(hwnd, SW_RESTORE)
+	created, destroyed, changed = watcher.Poll()
+	if created != nil || destroyed != nil || !changed {
+		t.Errorf("Poll() after restore = %v, %v, %v, want [], [], true", created, destroyed, changed)
+	}
+
+	// A window closed while minimized is destroyed
+	fake.ShowWindow(hwnd, SW_SHOWMINIMIZED)
+	watcher.Poll()
+	fake.RemoveWindow(hwnd)
+	created, destroyed, changed = watcher.Poll()
+	if created != nil || !reflect.DeepEqual(destroyed, []Handle{hwnd}) || changed {
+		t.Errorf("Poll() after close = %v, %v, %v, want [], [%v], false", created, destroyed, changed, hwnd)
+	}
+}
+
+func TestRetileMonitors(t *testing.T) {
+	fake := useFakeBackend(t)
+	useTiling(t, placement.Layout{Kind: placement.LayoutMasterStack}, map[string]placement.Layout{
+		"2": {Kind: placement.LayoutColumns},
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	first := fake.AddWindow(rect(100, 100, 500, 500))
+	second := fake.AddWindow(rect(600, 100, 900, 500))
+
+	RetileMonitors()
+	assertRect(t, fake, first, rect(0, 0, 960, 1080))
+	assertRect(t, fake, second, rect(960, 0, 1920, 1080))
+
+	// New windows are added to the end of the stack
+	third := fake.AddWindow(rect(100, 100, 500, 500))
+	RetileMonitors()
+	assertRect(t, fake, first, rect(0, 0, 960, 1080))
+	assertRect(t, fake, second, rect(960, 0, 1920, 540))
+	assertRect(t, fake, third, rect(960, 540, 1920, 1080))
+
+	// Moving a window re-tiles both monitors, the second monitor uses columns
+	fake.SetActiveWindow(first)
+	MoveActiveWindow(1)
+	assertRect(t, fake, first, rect(1920, 0, 3840, 1080))
+	assertRect(t, fake, second, rect(0, 0, 960, 1080))
+	assertRect(t, fake, third, rect(960, 0, 1920, 1080))
+
+	// Closing a window re-tiles the rest, maximized windows are left alone
+	fake.RemoveWindow(second)
+	fake.SetActiveWindow(third)
+	MaximizeActiveWindow(nil)
+	fourth := fake.AddWindow(rect(0, 0, 100, 100))
+	RetileMonitors()
+	assertRect(t, fake, third, rect(0, 0, 1920, 1080))
+	assertRect(t, fake, fourth, rect(0, 0, 1920, 1080))
+}
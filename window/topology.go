@@ -0,0 +1,155 @@
+package window
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// AutoRestoreLayouts re-applies the last layout of a monitor topology when the monitors change back to it
+var AutoRestoreLayouts = false
+
+// topologyPrefix is the prefix of the names of the layouts saved per monitor topology
+const topologyPrefix = "topology "
+
+// TopologyFingerprint identifies the monitor configuration by the position and resolution of the monitors.
+// The device names and handles are left out as they change when monitors are reconnected.
+func TopologyFingerprint(monitors []Monitor) string {
+	var parts []string
+	for _, m := range sortMonitors(monitors) {
+		r := m.Info.RCMonitor
+		part := fmt.Sprintf("%dx%d@%d,%d", r.Right-r.Left, r.Bottom-r.Top, r.Left, r.Top)
+		if m.Info.DwFlags&MONITORINFOF_PRIMARY != 0 {
+			part += "*"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ";")
+}
+
+// TopologyWatcher detects display configuration changes and restores the layout of the new topology.
+// While the topology is stable the layout is captured whenever the windows changed, so it can be saved
+// for the old topology when the monitors change. Poll is called when the displays changed and again while Pending,
+// Capture when windows changed.
+type TopologyWatcher struct {
+	fingerprint string
+	// pending is a new fingerprint that has to be seen twice before the layout is restored,
+	// Windows moves the windows around for a moment after a display change
+	pending string
+	last    []SavedWindow
+	// windows is the windowsFingerprint of the last captured layout
+	windows string
+}
+
+// NewTopologyWatcher returns a watcher for the current monitor topology
+func NewTopologyWatcher() *TopologyWatcher {
+	w := &TopologyWatcher{}
+	if monitors, err := GetMonitors(); err == nil {
+		w.fingerprint = TopologyFingerprint(monitors)
+		w.capture(monitors)
+	}
+	return w
+}
+
+// Poll checks the monitor topology and returns true if a layout was restored for a changed topology
+func (w *TopologyWatcher) Poll() bool {
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return false
+	}
+	fingerprint := TopologyFingerprint(monitors)
+
+	switch {
+	case fingerprint == w.fingerprint && w.pending == "":
+		w.capture(monitors)
+		return false
+	case fingerprint != w.pending:
+		// The topology changed, remember the layout of the old topology and wait for it to settle
+		log.Printf("DEBUG: Monitor topology changed from %q to %q\n", w.fingerprint, fingerprint)
+		if w.pending == "" && w.last != nil {
+			if err := writeSnapshot(topologyPrefix+w.fingerprint, w.last); err != nil {
+				log.Println("DEBUG: Error saving layout:", err)
+			}
+		}
+		w.pending = fingerprint
+		return false
+	}
+
+	// The new topology is stable, restore its last layout
+	w.fingerprint = fingerprint
+	w.pending = ""
+	defer w.capture(monitors)
+
+	snapshots, err := loadSnapshots()
+	if err != nil {
+		log.Println("DEBUG: Error loading layouts:", err)
+		return false
+	}
+	saved, exists := snapshots[topologyPrefix+fingerprint]
+	if !exists {
+		log.Printf("DEBUG: No layout saved for topology %q\n", fingerprint)
+		return false
+	}
+	log.Printf("DEBUG: Restoring layout of topology %q\n", fingerprint)
+	if err := applySnapshot(saved, monitors); err != nil {
+		log.Println("DEBUG: Error restoring layout:", err)
+		return false
+	}
+	return true
+}
+
+// Pending reports if the topology changed and Poll has to be called again once it settled
+func (w *TopologyWatcher) Pending() bool {
+	return w.pending != ""
+}
+
+// Capture captures the layout if the windows changed while the topology is stable
+func (w *TopologyWatcher) Capture() {
+	if w.pending != "" {
+		return
+	}
+	monitors, err := GetMonitors()
+	if err != nil {
+		log.Println("DEBUG: Error getting monitors:", err)
+		return
+	}
+	// Windows are moved right after a display change, those are not captured for the old topology
+	if TopologyFingerprint(monitors) != w.fingerprint {
+		return
+	}
+	w.capture(monitors)
+}
+
+// capture captures the layout if windows were opened, closed, moved or resized since the last capture.
+// Capturing looks up the process of every window, which is too expensive to repeat on every poll.
+func (w *TopologyWatcher) capture(monitors []Monitor) {
+	windows := windowsFingerprint()
+	if w.last != nil && windows == w.windows {
+		return
+	}
+	saved, err := captureSnapshot(monitors)
+	if err != nil {
+		log.Println("DEBUG: Error capturing layout:", err)
+		return
+	}
+	w.last, w.windows = saved, windows
+}
+
+// windowsFingerprint identifies the top-level windows by their handle, rect and show state
+func windowsFingerprint() string {
+	handles, err := backend.Windows()
+	if err != nil {
+		return ""
+	}
+	var parts []string
+	for _, hwnd := range handles {
+		rect, err := backend.WindowRect(hwnd)
+		if err != nil {
+			continue
+		}
+		showCmd, _ := backend.ShowState(hwnd)
+		parts = append(parts, fmt.Sprintf("%v:%d,%d,%d,%d:%d", hwnd, rect.Left, rect.Top, rect.Right, rect.Bottom, showCmd))
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,145 @@
+package window
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTopologyFingerprint(t *testing.T) {
+	docked := testMonitors(rect(0, 0, 1920, 1080), rect(1920, 0, 4480, 1440))
+	docked[0].Info.DwFlags = MONITORINFOF_PRIMARY
+
+	// The same monitors enumerated in a different order with new handles
+	reordered := testMonitors(rect(1920, 0, 4480, 1440), rect(0, 0, 1920, 1080))
+	reordered[1].Info.DwFlags = MONITORINFOF_PRIMARY
+
+	primaryChanged := testMonitors(rect(0, 0, 1920, 1080), rect(1920, 0, 4480, 1440))
+	primaryChanged[1].Info.DwFlags = MONITORINFOF_PRIMARY
+
+	resolutionChanged := testMonitors(rect(0, 0, 1920, 1080), rect(1920, 0, 3840, 1080))
+	resolutionChanged[0].Info.DwFlags = MONITORINFOF_PRIMARY
+
+	undocked := testMonitors(rect(0, 0, 1920, 1080))
+	undocked[0].Info.DwFlags = MONITORINFOF_PRIMARY
+
+	want := "1920x1080@0,0*;2560x1440@1920,0"
+	if got := TopologyFingerprint(docked); got != want {
+		t.Errorf("TopologyFingerprint() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		monitors []Monitor
+		same     bool
+	}{
+		{"reordered monitors", reordered, true},
+		{"primary monitor changed", primaryChanged, false},
+		{"resolution changed", resolutionChanged, false},
+		{"monitor removed", undocked, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopologyFingerprint(tt.monitors) == TopologyFingerprint(docked); got != tt.same {
+				t.Errorf("same fingerprint = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestTopologyWatcher(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := SnapshotFile
+	SnapshotFile = filepath.Join(t.TempDir(), "layouts.json")
+	t.Cleanup(func() {
+		SnapshotFile = previous
+	})
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	external := fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	browser := fake.AddWindow(rect(2020, 100, 2980, 640))
+	fake.SetWindowInfo(browser, WindowInfo{Exe: "firefox.exe", Class: "MozillaWindowClass", Title: "News"})
+
+	watcher := NewTopologyWatcher()
+	if watcher.Poll() {
+		t.Fatal("nothing should be restored while the topology is unchanged")
+	}
+
+	// Undock, Windows moves the browser to the laptop screen where it is arranged by hand
+	fake.RemoveMonitor(external)
+	fake.SetWindowRect(browser, rect(100, 100, 1060, 640))
+	if watcher.Poll() || watcher.Poll() {
+		t.Fatal("no layout is saved for the undocked topology yet")
+	}
+	fake.SetWindowRect(browser, rect(300, 300, 1260, 840))
+	watcher.Poll()
+
+	// Dock again, the browser returns to the external monitor once the topology is stable
+	external = fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	fake.SetWindowRect(browser, rect(100, 100, 1060, 640))
+	if watcher.Poll() {
+		t.Fatal("the layout should not be restored before the topology settled")
+	}
+	if !watcher.Poll() {
+		t.Fatal("the docked layout should be restored")
+	}
+	assertRect(t, fake, browser, rect(2020, 100, 2980, 640))
+
+	// Undock again, the hand arranged undocked layout is restored
+	fake.RemoveMonitor(external)
+	watcher.Poll()
+	if !watcher.Poll() {
+		t.Fatal("the undocked layout should be restored")
+	}
+	assertRect(t, fake, browser, rect(300, 300, 1260, 840))
+
+	names, err := SnapshotNames()
+	if err != nil || len(names) != 0 {
+		t.Errorf("SnapshotNames() = %v, %v, want no named layouts", names, err)
+	}
+}
+
+func TestTopologyWatcherCapturesChangedWindows(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowInfo(hwnd, WindowInfo{Exe: "firefox.exe", Title: "News"})
+	watcher := NewTopologyWatcher()
+
+	// The layout is not captured again while no window moved
+	fake.SetWindowInfo(hwnd, WindowInfo{Exe: "firefox.exe", Title: "Weather"})
+	watcher.Poll()
+	if len(watcher.last) != 1 || watcher.last[0].Title != "News" {
+		t.Fatalf("last = %+v, want the layout captured before", watcher.last)
+	}
+
+	fake.SetWindowRect(hwnd, rect(300, 300, 1260, 840))
+	watcher.Poll()
+	if len(watcher.last) != 1 || watcher.last[0].Title != "Weather" || watcher.last[0].Rect != rect(300, 300, 1260, 840) {
+		t.Errorf("last = %+v, want the moved window", watcher.last)
+	}
+}
+
+func TestTopologyWatcherCapture(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+	external := fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+	hwnd := fake.AddWindow(rect(2020, 100, 2980, 640))
+	watcher := NewTopologyWatcher()
+
+	fake.SetWindowRect(hwnd, rect(2120, 200, 3080, 740))
+	watcher.Capture()
+	if len(watcher.last) != 1 || watcher.last[0].Rect != rect(2120, 200, 3080, 740) {
+		t.Fatalf("last = %+v, want the moved window", watcher.last)
+	}
+
+	// Windows moved by a display change are not captured for the old topology
+	fake.RemoveMonitor(external)
+	fake.SetWindowRect(hwnd, rect(100, 100, 1060, 640))
+	watcher.Capture()
+	if watcher.Pending() || watcher.last[0].Rect != rect(2120, 200, 3080, 740) {
+		t.Errorf("last = %+v, want the layout before the display change", watcher.last)
+	}
+	watcher.Poll()
+	if !watcher.Pending() {
+		t.Error("the changed topology should be pending until it settled")
+	}
+}
@@ -0,0 +1,61 @@
+package window
+
+import "log"
+
+// WindowWatcher reports top-level windows that were created or destroyed between polls.
+// Minimized and cloaked windows are not enumerated, so windows are tracked until their handle is gone
+// and a restored window is not reported as created again.
+type WindowWatcher struct {
+	// seen holds every window enumerated so far that still exists
+	seen map[Handle]bool
+	// visible holds the windows of the last poll
+	visible map[Handle]bool
+}
+
+// NewWindowWatcher returns a watcher that knows the current windows, so the first poll only reports changes
+func NewWindowWatcher() *WindowWatcher {
+	w := &WindowWatcher{}
+	w.Poll()
+	return w
+}
+
+// Poll enumerates the windows and returns the ones that were created and destroyed since the last poll.
+// changed reports if the enumerated windows changed, which includes windows that were minimized or restored.
+func (w *WindowWatcher) Poll() (created, destroyed []Handle, changed bool) {
+	handles, err := backend.Windows()
+	if err != nil {
+		log.Println("DEBUG: Error enumerating windows:", err)
+		return nil, nil, false
+	}
+
+	current := make(map[Handle]bool, len(handles))
+	for _, hwnd := range handles {
+		current[hwnd] = true
+		if w.seen != nil && !w.seen[hwnd] {
+			created = append(created, hwnd)
+		}
+		if w.visible != nil && !w.visible[hwnd] {
+			changed = true
+		}
+	}
+	for hwnd := range w.visible {
+		if !current[hwnd] {
+			changed = true
+		}
+	}
+	for hwnd := range w.seen {
+		if !current[hwnd] && !backend.IsWindow(hwnd) {
+			destroyed = append(destroyed, hwnd)
+			delete(w.seen, hwnd)
+		}
+	}
+
+	if w.seen == nil {
+		w.seen = make(map[Handle]bool, len(handles))
+	}
+	for hwnd := range current {
+		w.seen[hwnd] = true
+	}
+	w.visible = current
+	return created, destroyed, changed
+}
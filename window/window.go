@@ -2,27 +2,34 @@ package window
 
 import (
 	"log"
-	"unsafe"
-
-	"golang.org/x/sys/windows"
+	"telewindow/placement"
 )
 
 // SizeByPixel controls if the window should be resized by pixel or percentage if the resolution of the monitors is different
 var SizeByPixel bool = false
 
-// Globals
-var (
-	user32                 = windows.NewLazySystemDLL("user32.dll")
-	procMoveWindow         = user32.NewProc("MoveWindow")
-	procShowWindow         = user32.NewProc("ShowWindow")
-	procGetWindowPlacement = user32.NewProc("GetWindowPlacement")
-	// procSetWindowPos       = user32.NewProc("SetWindowPos")
-	// procSetWindowPlacement = user32.NewProc("SetWindowPlacement")
-	// procSendMessage        = user32.NewProc("SendMessageW")
-	// procInvalidateRect     = user32.NewProc("InvalidateRect")
-	// procUpdateWindow       = user32.NewProc("UpdateWindow")
+// SplitCycle holds the fractions repeated splits cycle through per direction, a split is 50% if no fractions are set
+var SplitCycle = map[int][]float64{}
+
+// ScaleByDPI controls if pixel based moves keep the physical size of the window on monitors with different scaling
+var ScaleByDPI bool = false
+
+// Policies for ClampToMonitor
+const (
+	// ClampShrink shrinks and moves windows so they fit the target monitor
+	ClampShrink = "shrink"
+	// ClampShift only moves windows into the target monitor
+	ClampShift = "shift"
+	// ClampNone keeps the relative position even if the window hangs off the target monitor
+	ClampNone = "none"
 )
 
+// ClampToMonitor controls how moved windows are kept within the target monitor
+var ClampToMonitor = ClampShrink
+
+// UseWorkArea controls if windows are placed within the monitor work area (excluding the taskbar) or the full monitor
+var UseWorkArea bool = true
+
 const (
 	// ShowWindow commands
 	SW_MAXIMIZE = 3
@@ -50,24 +57,69 @@ const (
 	WPF_RESTORETOMAXIMIZED = 0x0002
 )
 
-type WINDOWPLACEMENT struct {
-	Length           uint32
-	Flags            uint32
-	ShowCmd          uint32
-	PtMinPosition    Point
-	PtMaxPosition    Point
-	RcNormalPosition RECT
+// placementOptions returns the placement options from the global settings
+func placementOptions() placement.Options {
+	opts := placement.Options{
+		Mode:        placement.Percentage,
+		UseWorkArea: UseWorkArea,
+		ScaleByDPI:  ScaleByDPI,
+	}
+	if SizeByPixel {
+		opts.Mode = placement.SizeByPixel
+	}
+	switch ClampToMonitor {
+	case ClampShrink:
+		opts.Clamp = placement.ClampShrink
+	case ClampShift:
+		opts.Clamp = placement.ClampShift
+	default:
+		opts.Clamp = placement.ClampNone
+	}
+	return opts
 }
 
 func MoveActiveWindow(direction int) {
 	log.Printf("DEBUG: Entering MoveActiveWindow() with direction: %d\n", direction)
+	moveActiveWindow(func(monitors []Monitor, currentMonitor *Monitor) *Monitor {
+		// Find the monitor in the desired direction
+		targetMonitor := findTargetMonitorWithFallback(monitors, currentMonitor, direction)
+		if targetMonitor == nil {
+			log.Println("DEBUG: No monitor found in the desired direction.")
+		}
+		return targetMonitor
+	})
+}
+
+// MoveActiveWindowToMonitor moves the active window to the monitor with the given index (1 is the leftmost monitor) or alias
+func MoveActiveWindowToMonitor(target string) {
+	log.Printf("DEBUG: Entering MoveActiveWindowToMonitor() with target: %s\n", target)
+	moveActiveWindow(func(monitors []Monitor, currentMonitor *Monitor) *Monitor {
+		targetMonitor := resolveMonitor(monitors, target)
+		if targetMonitor == nil {
+			log.Println("DEBUG: No monitor found for", target)
+			return nil
+		}
+		if targetMonitor.HMonitor == currentMonitor.HMonitor {
+			log.Println("DEBUG: Window is already on the target monitor.")
+			return nil
+		}
+		return targetMonitor
+	})
+}
+
+// moveActiveWindow moves the active window from its current monitor to the monitor returned by findTarget,
+// keeping its relative size and position
+func moveActiveWindow(findTarget func(monitors []Monitor, currentMonitor *Monitor) *Monitor) {
 	activeWindow, err := GetActiveWindow()
 	if err != nil {
 		log.Println("DEBUG: Error getting active window:", err)
 		return
 	}
+	if excluded(activeWindow, nil, false) {
+		return
+	}
 
-	rect, err := GetWindowRectWrapper(activeWindow)
+	rect, _, err := getVisibleWindowRect(activeWindow)
 	if err != nil {
 		log.Println("DEBUG: Error getting window rect:", err)
 		return
@@ -85,124 +137,162 @@ func MoveActiveWindow(direction int) {
 	}
 
 	// Find the monitor that the window is currently on
-	var currentMonitor *Monitor
-	var maxOverlap int64 = 0
-
-	for _, m := range monitors {
-		overlap := calculateOverlap(rect, &m.Info.RCMonitor)
-		if overlap > maxOverlap {
-			maxOverlap = overlap
-			currentMonitor = &m
-		}
-	}
-
+	currentMonitor := findCurrentMonitor(monitors, rect)
 	if currentMonitor == nil {
 		log.Println("DEBUG: Current monitor not found.")
 		return
 	}
 	log.Printf("DEBUG: Current monitor: %+v\n", currentMonitor.Info.RCMonitor)
 
-	// Find the monitor in the desired direction
-	targetMonitor := findTargetMonitor(monitors, currentMonitor, direction)
+	targetMonitor := findTarget(monitors, currentMonitor)
 	if targetMonitor == nil {
-		log.Println("DEBUG: No monitor found in the desired direction.")
 		return
 	}
 	log.Printf("DEBUG: Target monitor: %+v\n", targetMonitor.Info.RCMonitor)
 
-	// Calculate the new window position
-	var newX int32
-	var newY int32
-	var newWidth int32
-	var newHeight int32
-
-	if SizeByPixel {
-		// Calculate the window's current size and position pixel based
-		newWidth = rect.Right - rect.Left
-		newHeight = rect.Bottom - rect.Top
-
-		relativeX := rect.Left - currentMonitor.Info.RCMonitor.Left
-		relativeY := rect.Top - currentMonitor.Info.RCMonitor.Top
-
-		// Pixel based calculation
-		newX = targetMonitor.Info.RCMonitor.Left + relativeX
-		newY = targetMonitor.Info.RCMonitor.Top + relativeY
-	} else {
-		// Calculate the percentage of the window's size relative to the current monitor
-		currentMonitorWidth := float64(currentMonitor.Info.RCMonitor.Right - currentMonitor.Info.RCMonitor.Left)
-		currentMonitorHeight := float64(currentMonitor.Info.RCMonitor.Bottom - currentMonitor.Info.RCMonitor.Top)
-		windowWidth := float64(rect.Right - rect.Left)
-		windowHeight := float64(rect.Bottom - rect.Top)
+	if moveWindow(activeWindow, monitors, currentMonitor, targetMonitor, placementOptions()) {
+		log.Println("DEBUG: Window moved successfully.")
+		RetileMonitors()
+	}
+}
 
-		widthPercentage := windowWidth / currentMonitorWidth
-		heightPercentage := windowHeight / currentMonitorHeight
+// moveWindow moves the window from the current to the target monitor keeping its relative size and position.
+// A maximized window is maximized again on the target monitor. Windows that may not be resized keep their size,
+// monitors are the connected monitors the exclusions are matched with.
+func moveWindow(hwnd Handle, monitors []Monitor, currentMonitor, targetMonitor *Monitor, opts placement.Options) bool {
+	rect, frame, err := getVisibleWindowRect(hwnd)
+	if err != nil {
+		log.Println("DEBUG: Error getting window rect:", err)
+		return false
+	}
 
-		// Calculate the new size based on the target monitor's dimensions
-		targetMonitorWidth := float64(targetMonitor.Info.RCMonitor.Right - targetMonitor.Info.RCMonitor.Left)
-		targetMonitorHeight := float64(targetMonitor.Info.RCMonitor.Bottom - targetMonitor.Info.RCMonitor.Top)
+	if !resizable(hwnd, monitors) {
+		log.Println("DEBUG: Window may only be moved, keeping its size.")
+		opts.Mode = placement.SizeByPixel
+		opts.ScaleByDPI = false
+		if opts.Clamp == placement.ClampShrink {
+			opts.Clamp = placement.ClampShift
+		}
+	}
 
-		newWidth = int32(widthPercentage * targetMonitorWidth)
-		newHeight = int32(heightPercentage * targetMonitorHeight)
+	// Calculate the new window position
+	newRect := placement.Move(placement.Rect(*rect), currentMonitor.placement(), targetMonitor.placement(), opts)
 
-		// Calculate the new position
-		relativeXPercentage := float64(rect.Left-currentMonitor.Info.RCMonitor.Left) / currentMonitorWidth
-		relativeYPercentage := float64(rect.Top-currentMonitor.Info.RCMonitor.Top) / currentMonitorHeight
+	log.Printf("DEBUG: New window position: %+v\n", newRect)
 
-		// Percentage based calculation
-		newX = targetMonitor.Info.RCMonitor.Left + int32(relativeXPercentage*targetMonitorWidth)
-		newY = targetMonitor.Info.RCMonitor.Top + int32(relativeYPercentage*targetMonitorHeight)
+	// Verify that the window fits the target monitor
+	targetArea := RECT(targetMonitor.placement().Area(UseWorkArea))
+	if calculateOverlap((*RECT)(&newRect), &targetArea) < int64(newRect.Width())*int64(newRect.Height()) {
+		log.Println("DEBUG: Window does not fit within the target monitor.")
 	}
 
-	log.Printf("DEBUG: New window position: x=%d, y=%d, width=%d, height=%d\n", newX, newY, newWidth, newHeight)
+	recordWindowState(hwnd)
 
-	maximized, err := IsActiveWindowMaximized(&activeWindow)
+	maximized, err := IsActiveWindowMaximized(&hwnd)
 	if err != nil {
 		log.Println("DEBUG: Error checking if window is maximized:", err)
-		return
+		return false
 	}
 	if maximized {
 		log.Println("DEBUG: Window is maximized, restoring window.")
-		RestoreActiveWindow(&activeWindow)
+		RestoreActiveWindow(&hwnd)
+
+		// The invisible borders of the restored window can differ from the maximized window
+		if normal, err := GetWindowRectWrapper(hwnd); err == nil {
+			frame = getWindowFrame(hwnd, normal)
+		}
 
-		// Shrink the window by 5% and the newX and newY to make it centered
-		amount := 0.02 // Percentage
-		newWidth = int32(float64(newWidth) * (1 - amount))
-		newHeight = int32(float64(newHeight) * (1 - amount))
-		newX = newX + int32(float64(newWidth)*amount/2)
-		newY = newY + int32(float64(newHeight)*amount/2)
+		// Shrink the window by 2% to make it centered
+		newRect = placement.Shrink(newRect, 0.02)
 	}
 
 	log.Println("DEBUG: Moving window.")
 	// Move the window
-	ret, _, err := procMoveWindow.Call(
-		uintptr(activeWindow),
-		uintptr(newX),
-		uintptr(newY),
-		uintptr(newWidth),
-		uintptr(newHeight),
-		1, // Repaint
-	)
-	if ret == 0 {
+	err = setWindowRect(hwnd, RECT(frame.Window(newRect)))
+	if err != nil {
 		log.Println("DEBUG: MoveWindow failed:", err)
-		return
+		return false
 	}
 
 	if maximized {
 		log.Println("DEBUG: Window was maximized, maximizing window again.")
-		MaximizeActiveWindow(&activeWindow)
+		MaximizeActiveWindow(&hwnd)
 	}
-
-	log.Println("DEBUG: Window moved successfully.")
+	return true
 }
 
 func SplitActiveWindow(direction int) {
 	log.Println("DEBUG: Entering SplitWindow() with direction:", direction)
+	snapped := false
+	if activeWindow, err := GetActiveWindow(); err == nil {
+		snapped = isSnapped(activeWindow)
+	}
+	unsnap := false
+	snapActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		// Combine with a split in the perpendicular direction (left half + up = top-left quarter)
+		if combined, ok := placement.CombineSplit(window, area, direction); ok {
+			log.Println("DEBUG: Combining with the current split.")
+			return combined, true
+		}
+
+		// Cycle the size if the window is already split
+		fraction := placement.NextSplitFraction(window, area, direction, SplitCycle[direction])
+		log.Printf("DEBUG: Split fraction: %v\n", fraction)
+		newRect, ok := placement.SplitFraction(area, direction, fraction)
+		if !ok {
+			log.Println("DEBUG: Invalid direction. -1, 1, -2, 2.")
+		}
+
+		// Requesting the same split again returns the window to its size before the snap
+		if ok && snapped && placement.Snapped(window, newRect) {
+			unsnap = true
+			return placement.Rect{}, false
+		}
+		return newRect, ok
+	})
+	if unsnap {
+		log.Println("DEBUG: Window is already split, unsnapping window.")
+		UnsnapActiveWindow()
+	}
+	log.Println("DEBUG: Split finished for direction", direction)
+}
+
+// SplitActiveWindowCorner snaps the active window to a quarter of the monitor, e.g. (-1, -2) is the top-left quarter
+func SplitActiveWindowCorner(horizontal, vertical int) {
+	log.Println("DEBUG: Entering SplitActiveWindowCorner() with directions:", horizontal, vertical)
+	snapActiveWindow(func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool) {
+		newRect, ok := placement.Quarter(area, horizontal, vertical)
+		if !ok {
+			log.Println("DEBUG: Invalid corner. Horizontal -1, 1 and vertical -2, 2.")
+		}
+		return newRect, ok
+	})
+	log.Println("DEBUG: Corner split finished for directions", horizontal, vertical)
+}
+
+// snapActiveWindow places the active window like placeActiveWindow and remembers its state from before the snap,
+// so the window can be unsnapped
+func snapActiveWindow(calculate func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool)) {
+	arrangeActiveWindow(calculate, true)
+}
+
+// placeActiveWindow restores the active window if it is maximized and places it within the area of its current monitor.
+// calculate returns the new rect from the current window rect, the monitor area and the monitor.
+func placeActiveWindow(calculate func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool)) {
+	arrangeActiveWindow(calculate, false)
+}
+
+// arrangeActiveWindow places the active window, snap records the snap state of the window
+func arrangeActiveWindow(calculate func(window, area placement.Rect, monitor *Monitor) (placement.Rect, bool), snap bool) {
 	// 1. Get the active window
 	activeWindow, err := GetActiveWindow()
 	if err != nil {
 		log.Println("DEBUG: Error getting active window:", err)
 		return
 	}
+	if excluded(activeWindow, nil, false) {
+		return
+	}
 
 	// 2. Get the monitor that the window is on
 	monitors, err := GetMonitors()
@@ -217,18 +307,14 @@ func SplitActiveWindow(direction int) {
 		log.Println("DEBUG: Error getting window rect:", err)
 		return
 	}
+	windowRect := *rect
 
-	var currentMonitor *Monitor
-	var maxOverlap int64 = 0
-
-	for _, m := range monitors {
-		overlap := calculateOverlap(rect, &m.Info.RCMonitor)
-		if overlap > maxOverlap {
-			maxOverlap = overlap
-			currentMonitor = &m
-		}
-	}
+	// Snap the visible frame so neighboring windows meet without gaps
+	frame := getWindowFrame(activeWindow, rect)
+	visible := RECT(frame.Visible(placement.Rect(*rect)))
+	rect = &visible
 
+	currentMonitor := findCurrentMonitor(monitors, rect)
 	if currentMonitor == nil {
 		log.Println("DEBUG: Current monitor not found.")
 		return
@@ -236,37 +322,19 @@ func SplitActiveWindow(direction int) {
 	log.Printf("DEBUG: Current monitor: %+v\n", currentMonitor.Info.RCMonitor)
 
 	// 3. Get the monitor's dimensions
-	monitorRect := currentMonitor.Info.RCMonitor
+	monitorRect := currentMonitor.placement().Area(UseWorkArea)
 
 	// 4. Calculate the new window position and size
-	var newX, newY, newWidth, newHeight int32
-
-	if direction == -1 { // Left
-		newX = monitorRect.Left
-		newY = monitorRect.Top
-		newHeight = monitorRect.Bottom - monitorRect.Top
-		newWidth = (monitorRect.Right - monitorRect.Left) / 2
-	} else if direction == 1 { // Right
-		newX = monitorRect.Left + (monitorRect.Right-monitorRect.Left)/2
-		newY = monitorRect.Top
-		newHeight = monitorRect.Bottom - monitorRect.Top
-		newWidth = (monitorRect.Right - monitorRect.Left) / 2
-	} else if direction == -2 { // Up
-		newX = monitorRect.Left
-		newY = monitorRect.Top
-		newHeight = (monitorRect.Bottom - monitorRect.Top) / 2
-		newWidth = monitorRect.Right - monitorRect.Left
-	} else if direction == 2 { // Down
-		newX = monitorRect.Left
-		newY = monitorRect.Top + (monitorRect.Bottom-monitorRect.Top)/2
-		newHeight = (monitorRect.Bottom - monitorRect.Top) / 2
-		newWidth = monitorRect.Right - monitorRect.Left
-	} else {
-		log.Println("DEBUG: Invalid direction. -1, 1, -2, 2.")
+	newRect, ok := calculate(placement.Rect(*rect), monitorRect, currentMonitor)
+	if !ok {
+		return
+	}
+	if excluded(activeWindow, monitors, newRect.Width() != rect.Right-rect.Left || newRect.Height() != rect.Bottom-rect.Top) {
 		return
 	}
 
-	log.Printf("DEBUG: New window position: x=%d, y=%d, width=%d, height=%d\n", newX, newY, newWidth, newHeight)
+	log.Printf("DEBUG: New window position: %+v\n", newRect)
+	recordWindowState(activeWindow)
 
 	// 5. If window is maximized, restore it
 	maximized, err := IsActiveWindowMaximized(&activeWindow)
@@ -274,25 +342,34 @@ func SplitActiveWindow(direction int) {
 		log.Println("DEBUG: Error checking if window is maximized:", err)
 		return
 	}
+	restore := windowState{Rect: windowRect, ShowCmd: SW_SHOWNORMAL}
 	if maximized {
 		log.Println("DEBUG: Window is maximized, restoring window.")
 		RestoreActiveWindow(&activeWindow)
+
+		// Unsnapping maximizes the window again, keep its restored size for when it is restored later.
+		// The invisible borders of the restored window can differ from the maximized window.
+		if normal, err := GetWindowRectWrapper(activeWindow); err == nil {
+			restore.Rect = *normal
+			frame = getWindowFrame(activeWindow, normal)
+		}
+		restore.ShowCmd = SW_SHOWMAXIMIZED
+	}
+	if snap {
+		rememberSnapState(activeWindow, windowRect, restore)
 	}
 
 	// 6. Move and resize the window
 	log.Println("DEBUG: Moving and resizing window.")
-	ret, _, err := procMoveWindow.Call(
-		uintptr(activeWindow),
-		uintptr(newX),
-		uintptr(newY),
-		uintptr(newWidth),
-		uintptr(newHeight),
-		1, // Repaint
-	)
-	if ret == 0 {
+	snappedRect := RECT(frame.Window(newRect))
+	err = setWindowRect(activeWindow, snappedRect)
+	if err != nil {
 		log.Println("DEBUG: MoveWindow failed:", err)
 		return
 	}
+	if snap {
+		setSnappedRect(activeWindow, snappedRect)
+	}
 
 	// // Optionally, re-maximize if the window was maximized before
 	// if maximized {
@@ -300,12 +377,12 @@ func SplitActiveWindow(direction int) {
 	// 	MaximizeActiveWindow(&activeWindow)
 	// }
 
-	log.Println("DEBUG: Window moved successfully to", direction)
+	log.Println("DEBUG: Window moved successfully.")
 }
 
-func MaximizeActiveWindow(specificWindow *windows.Handle) {
+func MaximizeActiveWindow(specificWindow *Handle) {
 	log.Println("DEBUG: Entering MaximizeActiveWindow()")
-	var window windows.Handle
+	var window Handle
 	if specificWindow == nil {
 		activeWindow, err := GetActiveWindow()
 		if err != nil {
@@ -318,20 +395,17 @@ func MaximizeActiveWindow(specificWindow *windows.Handle) {
 		window = *specificWindow
 	}
 
-	ret, _, err := procShowWindow.Call(
-		uintptr(window),
-		uintptr(SW_MAXIMIZE),
-	)
-	if ret == 0 {
+	err := backend.ShowWindow(window, SW_MAXIMIZE)
+	if err != nil {
 		log.Println("MaximizeActiveWindow", "DEBUG: ShowWindow failed:", err)
 		return
 	}
 	log.Println("DEBUG: Window maximized successfully.")
 }
 
-func RestoreActiveWindow(specificWindow *windows.Handle) {
+func RestoreActiveWindow(specificWindow *Handle) {
 	log.Println("DEBUG: Entering RestoreActiveWindow()")
-	var window windows.Handle
+	var window Handle
 	if specificWindow == nil {
 		activeWindow, err := GetActiveWindow()
 		if err != nil {
@@ -344,20 +418,43 @@ func RestoreActiveWindow(specificWindow *windows.Handle) {
 		window = *specificWindow
 	}
 
-	ret, _, err := procShowWindow.Call(
-		uintptr(window),
-		uintptr(SW_RESTORE),
-	)
-	if ret == 0 {
-		log.Println("RestoreActiveWindow", "DEBUG: ShowWindow failed:", ret, err)
+	err := backend.ShowWindow(window, SW_RESTORE)
+	if err != nil {
+		log.Println("RestoreActiveWindow", "DEBUG: ShowWindow failed:", err)
 		return
 	}
 	log.Println("DEBUG: Window restored successfully.")
 }
 
-func IsActiveWindowMaximized(specificWindow *windows.Handle) (bool, error) {
+// ToggleMaximizeActiveWindow restores the active window if it is maximized and maximizes it otherwise
+func ToggleMaximizeActiveWindow() {
+	log.Println("DEBUG: Entering ToggleMaximizeActiveWindow()")
+	activeWindow, err := GetActiveWindow()
+	if err != nil {
+		log.Println("DEBUG: Error getting active window:", err)
+		return
+	}
+	if excluded(activeWindow, nil, true) {
+		return
+	}
+	maximized, err := IsActiveWindowMaximized(&activeWindow)
+	if err != nil {
+		log.Println("Error checking if window is maximized:", err)
+		return
+	}
+	recordWindowState(activeWindow)
+	if maximized {
+		log.Println("Window is maximized, restoring window.")
+		RestoreActiveWindow(&activeWindow)
+	} else {
+		log.Println("Window is not maximized, maximizing window.")
+		MaximizeActiveWindow(&activeWindow)
+	}
+}
+
+func IsActiveWindowMaximized(specificWindow *Handle) (bool, error) {
 	log.Println("DEBUG: Entering IsActiveWindowMaximized()")
-	var window windows.Handle
+	var window Handle
 	if specificWindow == nil {
 		activeWindow, err := GetActiveWindow()
 		if err != nil {
@@ -370,25 +467,19 @@ func IsActiveWindowMaximized(specificWindow *windows.Handle) (bool, error) {
 		window = *specificWindow
 	}
 
-	var wp WINDOWPLACEMENT
-	wp.Length = uint32(unsafe.Sizeof(wp))
-
-	ret, _, err := procGetWindowPlacement.Call(
-		uintptr(window),
-		uintptr(unsafe.Pointer(&wp)),
-	)
-	if ret == 0 {
+	showCmd, err := backend.ShowState(window)
+	if err != nil {
 		log.Println("DEBUG: GetWindowPlacement failed:", err)
 		return false, err
 	}
 
-	log.Printf("DEBUG: Window show command: %d\n", wp.ShowCmd)
-	return wp.ShowCmd == SW_SHOWMAXIMIZED, nil
+	log.Printf("DEBUG: Window show command: %d\n", showCmd)
+	return showCmd == SW_SHOWMAXIMIZED, nil
 }
 
-func IsActiveWindowMinimized(specificWindow *windows.Handle) (bool, error) {
+func IsActiveWindowMinimized(specificWindow *Handle) (bool, error) {
 	log.Println("DEBUG: Entering IsActiveWindowMinimized()")
-	var window windows.Handle
+	var window Handle
 	if specificWindow == nil {
 		activeWindow, err := GetActiveWindow()
 		if err != nil {
@@ -401,18 +492,12 @@ func IsActiveWindowMinimized(specificWindow *windows.Handle) (bool, error) {
 		window = *specificWindow
 	}
 
-	var wp WINDOWPLACEMENT
-	wp.Length = uint32(unsafe.Sizeof(wp))
-
-	ret, _, err := procGetWindowPlacement.Call(
-		uintptr(window),
-		uintptr(unsafe.Pointer(&wp)),
-	)
-	if ret == 0 {
+	showCmd, err := backend.ShowState(window)
+	if err != nil {
 		log.Println("DEBUG: GetWindowPlacement failed:", err)
 		return false, err
 	}
 
-	log.Printf("DEBUG: Window show command: %d\n", wp.ShowCmd)
-	return wp.ShowCmd == SW_SHOWMINIMIZED, nil
+	log.Printf("DEBUG: Window show command: %d\n", showCmd)
+	return showCmd == SW_SHOWMINIMIZED, nil
 }
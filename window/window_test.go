@@ -0,0 +1,315 @@
+package window
+
+import (
+	"telewindow/placement"
+	"testing"
+)
+
+// useFakeBackend installs a fake desktop for the duration of the test
+func useFakeBackend(t *testing.T) *FakeBackend {
+	t.Helper()
+	previous := CurrentBackend()
+	fake := NewFakeBackend()
+	SetBackend(fake)
+	// Handles of the fake desktop are reused across tests
+	historyMu.Lock()
+	histories = map[Handle]*windowHistory{}
+	historyMu.Unlock()
+	snapMu.Lock()
+	snapStates = map[Handle]*snapState{}
+	snapMu.Unlock()
+	tilingMu.Lock()
+	tilingOrder = nil
+	tilingMu.Unlock()
+	t.Cleanup(func() {
+		SetBackend(previous)
+	})
+	return fake
+}
+
+// useSizeByPixel sets SizeByPixel for the duration of the test
+func useSizeByPixel(t *testing.T, sizeByPixel bool) {
+	t.Helper()
+	previous := SizeByPixel
+	SizeByPixel = sizeByPixel
+	t.Cleanup(func() {
+		SizeByPixel = previous
+	})
+}
+
+func rect(left, top, right, bottom int32) RECT {
+	return RECT{Left: left, Top: top, Right: right, Bottom: bottom}
+}
+
+func assertRect(t *testing.T, fake *FakeBackend, hwnd Handle, want RECT) {
+	t.Helper()
+	w, ok := fake.Window(hwnd)
+	if !ok {
+		t.Fatalf("window %v does not exist", hwnd)
+	}
+	if w.Rect != want {
+		t.Errorf("window rect = %+v, want %+v", w.Rect, want)
+	}
+}
+
+func TestMoveActiveWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		sizeByPixel bool
+		direction   int
+		window      RECT
+		want        RECT
+	}{
+		{"right percentage", false, 1, rect(100, 100, 1060, 640), rect(2120, 200, 4040, 1280)},
+		{"right pixel", true, 1, rect(100, 100, 1060, 640), rect(2020, 100, 2980, 640)},
+		{"left percentage", false, -1, rect(2120, 200, 4040, 1280), rect(100, 100, 1060, 640)},
+		{"no monitor above", false, -2, rect(100, 100, 1060, 640), rect(100, 100, 1060, 640)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			useSizeByPixel(t, tt.sizeByPixel)
+			fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+			fake.AddMonitor(rect(1920, 0, 5760, 2160), rect(1920, 0, 5760, 2080))
+			hwnd := fake.AddWindow(tt.window)
+
+			MoveActiveWindow(tt.direction)
+
+			assertRect(t, fake, hwnd, tt.want)
+		})
+	}
+}
+
+func TestMoveActiveWindowScaleByDPI(t *testing.T) {
+	fake := useFakeBackend(t)
+	useSizeByPixel(t, true)
+	previous := ScaleByDPI
+	ScaleByDPI = true
+	t.Cleanup(func() {
+		ScaleByDPI = previous
+	})
+	laptop := fake.AddMonitor(rect(0, 0, 2880, 1800), rect(0, 0, 2880, 1800))
+	fake.SetMonitorDPI(laptop, 144)
+	fake.AddMonitor(rect(2880, 0, 4800, 1080), rect(2880, 0, 4800, 1080))
+	hwnd := fake.AddWindow(rect(300, 150, 1800, 1050))
+
+	MoveActiveWindow(1)
+
+	assertRect(t, fake, hwnd, rect(3080, 100, 4080, 700))
+}
+
+func TestMoveActiveWindowClamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		clamp string
+		want  RECT
+	}{
+		{"shrink", ClampShrink, rect(1920, 0, 3840, 1080)},
+		{"shift", ClampShift, rect(1920, 0, 4320, 1200)},
+		{"none", ClampNone, rect(2020, 100, 4420, 1300)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			useSizeByPixel(t, true)
+			previous := ClampToMonitor
+			ClampToMonitor = tt.clamp
+			t.Cleanup(func() {
+				ClampToMonitor = previous
+			})
+			fake.AddMonitor(rect(-640, 0, 1920, 1440), rect(-640, 0, 1920, 1440))
+			fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+			hwnd := fake.AddWindow(rect(-540, 100, 1860, 1300))
+
+			MoveActiveWindow(1)
+
+			assertRect(t, fake, hwnd, tt.want)
+		})
+	}
+}
+
+func TestMoveActiveWindowMaximized(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowFrame(hwnd, placement.Frame{Left: 7, Top: 0, Right: 7, Bottom: 7})
+	MaximizeActiveWindow(nil)
+
+	MoveActiveWindow(1)
+
+	maximized, err := IsActiveWindowMaximized(&hwnd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maximized {
+		t.Error("window should still be maximized after the move")
+	}
+	assertRect(t, fake, hwnd, rect(1920, 0, 3840, 1040))
+
+	// The restored window keeps the invisible borders around its visible frame
+	RestoreActiveWindow(nil)
+	assertRect(t, fake, hwnd, rect(1931, 10, 3826, 1036))
+}
+
+func TestSplitActiveWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction int
+		want      RECT
+	}{
+		{"left", -1, rect(1920, 0, 2880, 1080)},
+		{"right", 1, rect(2880, 0, 3840, 1080)},
+		{"up", -2, rect(1920, 0, 3840, 540)},
+		{"down", 2, rect(1920, 540, 3840, 1080)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1080))
+			fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1920, 0, 3840, 1080))
+			hwnd := fake.AddWindow(rect(2000, 100, 2960, 640))
+
+			SplitActiveWindow(tt.direction)
+
+			assertRect(t, fake, hwnd, tt.want)
+		})
+	}
+}
+
+func TestSplitActiveWindowWorkArea(t *testing.T) {
+	tests := []struct {
+		name        string
+		useWorkArea bool
+		direction   int
+		want        RECT
+	}{
+		{"left work area", true, -1, rect(1970, 0, 2905, 1080)},
+		{"right work area", true, 1, rect(2905, 0, 3840, 1080)},
+		{"left full monitor", false, -1, rect(1920, 0, 2880, 1080)},
+		{"up work area", true, -2, rect(1970, 0, 3840, 540)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeBackend(t)
+			previous := UseWorkArea
+			UseWorkArea = tt.useWorkArea
+			t.Cleanup(func() {
+				UseWorkArea = previous
+			})
+			// Taskbar on the bottom of the first monitor and on the left of the second monitor
+			fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+			fake.AddMonitor(rect(1920, 0, 3840, 1080), rect(1970, 0, 3840, 1080))
+			hwnd := fake.AddWindow(rect(2000, 100, 2960, 640))
+
+			SplitActiveWindow(tt.direction)
+
+			assertRect(t, fake, hwnd, tt.want)
+		})
+	}
+}
+
+func TestSplitActiveWindowCycle(t *testing.T) {
+	fake := useFakeBackend(t)
+	previous := SplitCycle
+	SplitCycle = map[int][]float64{-1: {0.5, 0.25, 0.75}}
+	t.Cleanup(func() {
+		SplitCycle = previous
+	})
+	fake.AddMonitor(rect(0, 0, 2000, 1000), rect(0, 0, 2000, 1000))
+	hwnd := fake.AddWindow(rect(100, 100, 900, 700))
+
+	for _, want := range []RECT{
+		rect(0, 0, 1000, 1000),
+		rect(0, 0, 500, 1000),
+		rect(0, 0, 1500, 1000),
+		rect(0, 0, 1000, 1000),
+	} {
+		SplitActiveWindow(-1)
+		assertRect(t, fake, hwnd, want)
+	}
+
+	// A different direction starts its own cycle
+	SplitActiveWindow(1)
+	assertRect(t, fake, hwnd, rect(1000, 0, 2000, 1000))
+}
+
+func TestSplitActiveWindowCorner(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 3840, 2160), rect(0, 0, 3840, 2160))
+	hwnd := fake.AddWindow(rect(100, 100, 900, 700))
+
+	SplitActiveWindowCorner(1, 2)
+	assertRect(t, fake, hwnd, rect(1920, 1080, 3840, 2160))
+
+	SplitActiveWindowCorner(-1, -2)
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 1080))
+}
+
+func TestSplitActiveWindowCombine(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 3840, 2160), rect(0, 0, 3840, 2160))
+	hwnd := fake.AddWindow(rect(100, 100, 900, 700))
+
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 2160))
+
+	SplitActiveWindow(-2)
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 1080))
+
+	SplitActiveWindow(1)
+	assertRect(t, fake, hwnd, rect(1920, 0, 3840, 1080))
+}
+
+func TestSplitActiveWindowInvisibleBorders(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	frame := placement.Frame{Left: 7, Top: 0, Right: 7, Bottom: 7}
+
+	// The visible frames of both halves meet in the middle and touch the monitor edges
+	left := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowFrame(left, frame)
+	SplitActiveWindow(-1)
+	assertRect(t, fake, left, rect(-7, 0, 967, 1047))
+
+	right := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowFrame(right, frame)
+	SplitActiveWindow(1)
+	assertRect(t, fake, right, rect(953, 0, 1927, 1047))
+}
+
+func TestSplitMaximizedWindowInvisibleBorders(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+
+	// The maximized window has no invisible borders, the restored window has
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+	fake.SetWindowFrame(hwnd, placement.Frame{Left: 7, Top: 0, Right: 7, Bottom: 7})
+	MaximizeActiveWindow(nil)
+	SplitActiveWindow(-1)
+	assertRect(t, fake, hwnd, rect(-7, 0, 967, 1047))
+}
+
+func TestMaximizeAndRestoreActiveWindow(t *testing.T) {
+	fake := useFakeBackend(t)
+	fake.AddMonitor(rect(0, 0, 1920, 1080), rect(0, 0, 1920, 1040))
+	hwnd := fake.AddWindow(rect(100, 100, 1060, 640))
+
+	MaximizeActiveWindow(nil)
+	assertRect(t, fake, hwnd, rect(0, 0, 1920, 1040))
+
+	RestoreActiveWindow(nil)
+	assertRect(t, fake, hwnd, rect(100, 100, 1060, 640))
+
+	maximized, err := IsActiveWindowMaximized(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maximized {
+		t.Error("window should not be maximized after restore")
+	}
+}